@@ -0,0 +1,166 @@
+package bigger
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// bigComplex is a complex number re + im i with bigDecimal parts. It is
+// immutable. Add, Subtract, Multiply and Pow into exact results are exact,
+// Divide and Abs round with a mathContext.
+type bigComplex struct {
+	re *bigDecimal
+	im *bigDecimal
+}
+
+// NewBigComplex returns re + im i.
+func NewBigComplex(re, im *bigDecimal) *bigComplex {
+	return &bigComplex{re: re, im: im}
+}
+
+// BigComplexValueOf returns re + im i with each part the shortest decimal
+// that converts back to the same float64, as strconv formats it.
+func BigComplexValueOf(re, im float64) *bigComplex {
+	return &bigComplex{re: bigDecimalFromFloat64(re), im: bigDecimalFromFloat64(im)}
+}
+
+func bigDecimalFromFloat64(f float64) *bigDecimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(errors.New("Infinite or NaN"))
+	}
+	return NewBigDecimalString(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// NewBigComplexString parses "a+bi", "a-bi", a real "a" or an imaginary "bi",
+// where "i" alone is one. The parts are bigDecimal strings, spaces are
+// ignored.
+func NewBigComplexString(val string) *bigComplex {
+	val = strings.ReplaceAll(val, " ", "")
+	if val == "" {
+		panic(errors.New("BigComplex: empty string"))
+	}
+	if !strings.HasSuffix(val, "i") {
+		return &bigComplex{re: NewBigDecimalString(val), im: zeroValueOf(0)}
+	}
+	val = val[:len(val)-1]
+	// the imaginary part starts at the last sign that is not an exponent's
+	split := 0
+	for i := len(val) - 1; i > 0; i-- {
+		if (val[i] == '+' || val[i] == '-') && val[i-1] != 'e' && val[i-1] != 'E' {
+			split = i
+			break
+		}
+	}
+	re := zeroValueOf(0)
+	if split > 0 {
+		re = NewBigDecimalString(val[:split])
+	}
+	im := val[split:]
+	switch im {
+	case "", "+":
+		im = "1"
+	case "-":
+		im = "-1"
+	}
+	return &bigComplex{re: re, im: NewBigDecimalString(im)}
+}
+
+// Real returns the real part.
+func (z *bigComplex) Real() *bigDecimal {
+	return z.re
+}
+
+// Imag returns the imaginary part.
+func (z *bigComplex) Imag() *bigDecimal {
+	return z.im
+}
+
+// Add returns z + val.
+func (z *bigComplex) Add(val *bigComplex) *bigComplex {
+	return &bigComplex{re: z.re.Add(val.re), im: z.im.Add(val.im)}
+}
+
+// Subtract returns z - val.
+func (z *bigComplex) Subtract(val *bigComplex) *bigComplex {
+	return &bigComplex{re: z.re.Subtract(val.re), im: z.im.Subtract(val.im)}
+}
+
+// Multiply returns z val.
+func (z *bigComplex) Multiply(val *bigComplex) *bigComplex {
+	return &bigComplex{
+		re: z.re.Multiply(val.re).Subtract(z.im.Multiply(val.im)),
+		im: z.re.Multiply(val.im).Add(z.im.Multiply(val.re)),
+	}
+}
+
+// Divide returns z / val with each part rounded to mc, from the exact
+// (a + bi)(c - di) / (c^2 + d^2).
+func (z *bigComplex) Divide(val *bigComplex, mc *mathContext) *bigComplex {
+	den := val.norm()
+	if den.signum() == 0 {
+		panic(errors.New("Division by zero"))
+	}
+	n := z.Multiply(val.Conjugate())
+	return &bigComplex{re: n.re.DivideMathContext(den, mc), im: n.im.DivideMathContext(den, mc)}
+}
+
+// Negate returns -z.
+func (z *bigComplex) Negate() *bigComplex {
+	return &bigComplex{re: z.re.Negate(), im: z.im.Negate()}
+}
+
+// Conjugate returns re - im i.
+func (z *bigComplex) Conjugate() *bigComplex {
+	return &bigComplex{re: z.re, im: z.im.Negate()}
+}
+
+// Abs returns the magnitude sqrt(re^2 + im^2) rounded to mc.
+func (z *bigComplex) Abs(mc *mathContext) *bigDecimal {
+	return z.norm().Sqrt(mc)
+}
+
+// norm returns re^2 + im^2 exactly.
+func (z *bigComplex) norm() *bigDecimal {
+	return z.re.Multiply(z.re).Add(z.im.Multiply(z.im))
+}
+
+// Pow returns z^n with each part rounded to mc, computed exactly before the
+// rounding. A negative n divides one by z^-n.
+func (z *bigComplex) Pow(n types.Int, mc *mathContext) *bigComplex {
+	if n < 0 {
+		one := &bigComplex{re: BigDecimalValueOf(1), im: zeroValueOf(0)}
+		return one.Divide(z.Pow(-n, UNLIMITED), mc)
+	}
+	result := &bigComplex{re: BigDecimalValueOf(1), im: zeroValueOf(0)}
+	for base := z; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			result = result.Multiply(base)
+		}
+		if n > 1 {
+			base = base.Multiply(base)
+		}
+	}
+	return result.Round(mc)
+}
+
+// Round returns z with each part rounded to mc.
+func (z *bigComplex) Round(mc *mathContext) *bigComplex {
+	return &bigComplex{re: z.re.Round(mc), im: z.im.Round(mc)}
+}
+
+// Equals reports whether both parts are equal in value, whatever their scales.
+func (z *bigComplex) Equals(val *bigComplex) bool {
+	return z.re.CompareTo(val.re) == 0 && z.im.CompareTo(val.im) == 0
+}
+
+// String returns "a+bi" or "a-bi".
+func (z *bigComplex) String() string {
+	if z.im.signum() < 0 {
+		return z.re.String() + "-" + z.im.Negate().String() + "i"
+	}
+	return z.re.String() + "+" + z.im.String() + "i"
+}
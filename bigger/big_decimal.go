@@ -4,7 +4,6 @@ import (
 	"errors"
 	"github.com/sineycoder/go-bigger/tool"
 	"github.com/sineycoder/go-bigger/types"
-	"math"
 	"sync"
 )
 
@@ -103,7 +102,7 @@ func init() {
 		newBigDecimalByBigInteger(BigIntegerValueOf(7), 7, 0, 1),
 		newBigDecimalByBigInteger(BigIntegerValueOf(8), 8, 0, 1),
 		newBigDecimalByBigInteger(BigIntegerValueOf(9), 9, 0, 1),
-		newBigDecimalByBigInteger(TEN, 10, 0, 1),
+		newBigDecimalByBigInteger(TEN, 10, 0, 2),
 	}
 	p_ZERO_SCALED_BY = []*bigDecimal{
 		p_ZERO_THROUGH_TEN[0],
@@ -363,7 +362,7 @@ func bigDigitLength(b *bigInteger) types.Int {
 	if b.signum == 0 {
 		return 1
 	}
-	r := ((b.BitLength() + 1) * 646456993).ShiftR(31)
+	r := types.Int(((b.BitLength() + 1).ToLong() * 646456993) >> 31)
 	if b.compareMagnitute(bigTenToThe(r)) < 0 {
 		return r
 	}
@@ -494,7 +493,7 @@ func longDigitLength(x types.Long) types.Int {
 		if x < 10 {
 			return 1
 		}
-		r := ((64 - NumberOfLeadingZeros(x.ToInt()) + 1) * 1233).ShiftR(12)
+		r := ((64 - NumberOfLeadingZerosForLong(x) + 1) * 1233).ShiftR(12)
 		tab := p_LONG_TEN_POWERS_TABLE
 		if r >= types.Int(len(tab)) || x < tab[r] {
 			return r
@@ -717,7 +716,7 @@ func (b *bigDecimal) layoutChars(sci bool) string {
 	var coeff []rune
 	var offset types.Int
 	if b.intCompact != MIN_INT64 {
-		offset = sbHelper.putIntCompact(types.Long(math.Abs(float64(b.intCompact))))
+		offset = sbHelper.putIntCompact(b.intCompact.Abs())
 		coeff = sbHelper.getCompactCharArray()
 	} else {
 		offset = 0
@@ -805,9 +804,16 @@ func (b *bigDecimal) Add(augend *bigDecimal) *bigDecimal {
 	if b.intCompact != MIN_INT64 {
 		if augend.intCompact != MIN_INT64 {
 			return b.add(b.intCompact, b.scale, augend.intCompact, augend.scale)
+		} else {
+			return add4_(b.intCompact, b.scale, augend.intVal, augend.scale)
+		}
+	} else {
+		if augend.intCompact != MIN_INT64 {
+			return add4_(augend.intCompact, augend.scale, b.intVal, b.scale)
+		} else {
+			return add4__(b.intVal, b.scale, augend.intVal, augend.scale)
 		}
 	}
-	return nil
 }
 
 func (b *bigDecimal) add(xs types.Long, scale1 types.Int, ys types.Long, scale2 types.Int) *bigDecimal {
@@ -820,7 +826,7 @@ func (b *bigDecimal) add(xs types.Long, scale1 types.Int, ys types.Long, scale2
 		if scaledX != MIN_INT64 {
 			return add3(scaledX, ys, scale2)
 		} else {
-			bigsum := bigMultiplyPowerTen(xs, raise)
+			bigsum := bigMultiplyPowerTen(xs, raise).add(ys)
 			if (xs ^ ys) >= 0 {
 				return newBigDecimalByBigInteger(bigsum, MIN_INT64, scale2, 0)
 			} else {
@@ -1241,7 +1247,7 @@ func divide6(dividend types.Long, dividendScale types.Int, divisor types.Long, d
 		scaledDividend := bigMultiplyPowerTen(dividend, raise)
 		return divideAndRoundHalfByBigInteger5(scaledDividend, divisor, scale, roundingMode, scale)
 	} else {
-		newScale := checkScale(divisor, divisorScale.ToLong()-scale.ToLong())
+		newScale := checkScale(divisor, dividendScale.ToLong()-scale.ToLong())
 		raise := newScale - divisorScale
 		if raise < types.Int(len(p_LONG_TEN_POWERS_TABLE)) {
 			ys := divisor
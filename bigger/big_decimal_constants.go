@@ -0,0 +1,128 @@
+package bigger
+
+import (
+	"math"
+	"sync"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// constantCache holds a constant times 2^bits rounded down. It is computed
+// again, with some bits to spare, when more bits are asked for.
+type constantCache struct {
+	mu      sync.Mutex
+	bits    types.Int
+	value   *bigInteger
+	compute func(w types.Int) *bigInteger
+}
+
+var (
+	// ln 2 = 2 atanh(1/3)
+	lnTwo = &constantCache{compute: func(w types.Int) *bigInteger {
+		return atanhInverse(3, w+4).shiftLeft(1).shiftRight(4)
+	}}
+	// ln 10 = 3 ln 2 + ln(5/4) = 6 atanh(1/3) + 2 atanh(1/9)
+	lnTen = &constantCache{compute: func(w types.Int) *bigInteger {
+		a := atanhInverse(3, w+4).Multiply(BigIntegerValueOf(6))
+		return a.Add(atanhInverse(9, w+4).shiftLeft(1)).shiftRight(4)
+	}}
+	piConstant = &constantCache{compute: chudnovsky}
+	eConstant  = &constantCache{compute: func(w types.Int) *bigInteger {
+		return expSeries(ONE, 0, w)
+	}}
+	sqrtTwo = &constantCache{compute: func(w types.Int) *bigInteger {
+		return TWO.shiftLeft(2 * w).Sqrt()
+	}}
+)
+
+// Pi returns pi rounded to mc. Pi, E, Ln2 and Sqrt2 are cached at the highest
+// precision asked for so far.
+func Pi(mc *mathContext) *bigDecimal {
+	return roundConstant(piConstant, mc)
+}
+
+// E returns e, the base of the natural logarithm, rounded to mc.
+func E(mc *mathContext) *bigDecimal {
+	return roundConstant(eConstant, mc)
+}
+
+// Ln2 returns the natural logarithm of 2 rounded to mc.
+func Ln2(mc *mathContext) *bigDecimal {
+	return roundConstant(lnTwo, mc)
+}
+
+// Sqrt2 returns the square root of 2 rounded to mc.
+func Sqrt2(mc *mathContext) *bigDecimal {
+	return roundConstant(sqrtTwo, mc)
+}
+
+// roundConstant returns the cached constant rounded to mc.
+func roundConstant(c *constantCache, mc *mathContext) *bigDecimal {
+	checkInexact(mc)
+	return zivRound(workingBits(mc), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		return c.get(w), TWO, -w, 0
+	})
+}
+
+// get returns the constant times 2^w within two units.
+func (c *constantCache) get(w types.Int) *bigInteger {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.bits < w {
+		bits := w + 64
+		if grown := c.bits + c.bits/2; grown > bits {
+			bits = grown
+		}
+		c.value, c.bits = c.compute(bits), bits
+	}
+	return c.value.shiftRight(c.bits - w)
+}
+
+// atanhInverse returns atanh(1/q) 2^w within one unit for q >= 2, the sum of
+// 1 / ((2k+1) q^(2k+1)) by binary splitting.
+func atanhInverse(q types.Long, w types.Int) *bigInteger {
+	n := types.Int(float64(w+2)/(2*math.Log2(float64(q)))) + 2
+	b, d, t := atanhSplit(BigIntegerValueOf(q*q), 0, n)
+	return t.shiftLeft(w).Divide(b.Multiply(d).Multiply(BigIntegerValueOf(q)))
+}
+
+// atanhSplit returns B, D and T for the terms k in [a, b), B the product of
+// the 2k+1, D that of q2 but for k = 0, and T / (B D) their sum relative to
+// the term before a.
+func atanhSplit(q2 *bigInteger, a, b types.Int) (*bigInteger, *bigInteger, *bigInteger) {
+	if b-a == 1 {
+		d := q2
+		if a == 0 {
+			d = ONE
+		}
+		return BigIntegerValueOf(types.Long(2*a + 1)), d, ONE
+	}
+	c := (a + b) / 2
+	b1, d1, t1 := atanhSplit(q2, a, c)
+	b2, d2, t2 := atanhSplit(q2, c, b)
+	return b1.Multiply(b2), d1.Multiply(d2), b2.Multiply(d2).Multiply(t1).Add(b1.Multiply(t2))
+}
+
+// chudnovsky returns pi 2^w within two units, 426880 sqrt(10005) over the sum
+// of (-1)^k (6k)! (13591409 + 545140134k) / ((3k)! k!^3 640320^3k), whose
+// terms shrink by 47 bits each.
+func chudnovsky(w types.Int) *bigInteger {
+	_, q, t := chudnovskySplit(1, w/47+2)
+	root := BigIntegerValueOf(10005).shiftLeft(2 * w).Sqrt()
+	return root.Multiply(q).Multiply(BigIntegerValueOf(426880)).Divide(q.Multiply(BigIntegerValueOf(13591409)).Add(t))
+}
+
+// chudnovskySplit returns P, Q and T for the terms k in [a, b) of the
+// Chudnovsky series, T / Q their sum relative to the term before a.
+func chudnovskySplit(a, b types.Int) (*bigInteger, *bigInteger, *bigInteger) {
+	if b-a == 1 {
+		k := a.ToLong()
+		p := BigIntegerValueOf(-(6*k - 5)).Multiply(BigIntegerValueOf(2*k - 1)).Multiply(BigIntegerValueOf(6*k - 1))
+		q := BigIntegerValueOf(k * k).Multiply(BigIntegerValueOf(k)).Multiply(BigIntegerValueOf(10939058860032000))
+		return p, q, p.Multiply(BigIntegerValueOf(13591409 + 545140134*k))
+	}
+	c := (a + b) / 2
+	p1, q1, t1 := chudnovskySplit(a, c)
+	p2, q2, t2 := chudnovskySplit(c, b)
+	return p1.Multiply(p2), q1.Multiply(q2), t1.Multiply(q2).Add(p1.Multiply(t2))
+}
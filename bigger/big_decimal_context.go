@@ -0,0 +1,216 @@
+package bigger
+
+import (
+	"errors"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+var (
+	// DECIMAL32 has the 7 digits of the IEEE 754R decimal32 format.
+	DECIMAL32 = NewMathContext(7, ROUND_HALF_EVEN)
+	// DECIMAL64 has the 16 digits of the IEEE 754R decimal64 format.
+	DECIMAL64 = NewMathContext(16, ROUND_HALF_EVEN)
+	// DECIMAL128 has the 34 digits of the IEEE 754R decimal128 format.
+	DECIMAL128 = NewMathContext(34, ROUND_HALF_EVEN)
+	// UNLIMITED asks for exact results.
+	UNLIMITED = NewMathContext(0, ROUND_HALF_UP)
+)
+
+// NewMathContext returns a context rounding to precision significant digits
+// with the roundingMode. A precision of zero asks for exact results.
+func NewMathContext(precision types.Int, roundingMode RoundingMode) *mathContext {
+	if precision < 0 {
+		panic(errors.New("Digits < 0"))
+	}
+	if roundingMode < ROUND_UP || roundingMode > ROUND_UNNECESSARY {
+		panic(errors.New("Invalid rounding mode"))
+	}
+	return &mathContext{precision: precision, roundingMode: roundingMode}
+}
+
+// Precision returns the number of significant digits, zero for unlimited.
+func (mc *mathContext) Precision() types.Int {
+	return mc.precision
+}
+
+// RoundingMode returns the rounding mode.
+func (mc *mathContext) RoundingMode() RoundingMode {
+	return mc.roundingMode
+}
+
+// Signum returns -1, 0 or 1 as the value is negative, zero or positive.
+func (b *bigDecimal) Signum() types.Int {
+	return b.signum()
+}
+
+// Scale returns the scale, the number of digits right of the point.
+func (b *bigDecimal) Scale() types.Int {
+	return b.scale
+}
+
+// UnscaledValue returns the unscaled value, b = UnscaledValue() 10^-Scale().
+func (b *bigDecimal) UnscaledValue() *bigInteger {
+	return b.inflated()
+}
+
+// Precision returns the number of digits of the unscaled value, one for zero.
+func (b *bigDecimal) Precision() types.Int {
+	if b.precision == 0 {
+		if b.intCompact != MIN_INT64 {
+			b.precision = longDigitLength(b.intCompact)
+		} else {
+			b.precision = bigDigitLength(b.intVal)
+		}
+	}
+	return b.precision
+}
+
+// Negate returns -b with the same scale.
+func (b *bigDecimal) Negate() *bigDecimal {
+	if b.intCompact != MIN_INT64 {
+		return valueOf(-b.intCompact, b.scale)
+	}
+	return newBigDecimalByBigInteger(b.intVal.negate(), MIN_INT64, b.scale, b.precision)
+}
+
+// Abs returns |b| with the same scale.
+func (b *bigDecimal) Abs() *bigDecimal {
+	if b.signum() < 0 {
+		return b.Negate()
+	}
+	return b
+}
+
+// MovePointLeft returns b with its point moved n places left, b 10^-n with
+// the scale max(b.Scale() + n, 0). A negative n moves it right, and a zero n
+// returns b itself.
+func (b *bigDecimal) MovePointLeft(n types.Int) *bigDecimal {
+	if n == 0 {
+		return b
+	}
+	return b.movePoint(b.checkScale(b.scale.ToLong() + n.ToLong()))
+}
+
+// MovePointRight returns b with its point moved n places right, b 10^n with
+// the scale max(b.Scale() - n, 0). A negative n moves it left, and a zero n
+// returns b itself.
+func (b *bigDecimal) MovePointRight(n types.Int) *bigDecimal {
+	if n == 0 {
+		return b
+	}
+	return b.movePoint(b.checkScale(b.scale.ToLong() - n.ToLong()))
+}
+
+// movePoint returns the unscaled value of b at newScale, padded with zeros
+// to the scale zero when newScale is negative.
+func (b *bigDecimal) movePoint(newScale types.Int) *bigDecimal {
+	num := newBigDecimalByBigInteger(b.intVal, b.intCompact, newScale, 0)
+	if num.scale < 0 {
+		return num.SetScale(0, ROUND_UNNECESSARY)
+	}
+	return num
+}
+
+// ScaleByPowerOfTen returns b 10^n with the unscaled value of b and the scale
+// b.Scale() - n.
+func (b *bigDecimal) ScaleByPowerOfTen(n types.Int) *bigDecimal {
+	return newBigDecimalByBigInteger(b.intVal, b.intCompact, b.checkScale(b.scale.ToLong()-n.ToLong()), b.precision)
+}
+
+// CompareTo returns -1, 0 or 1 as b is less than, equal to or greater than
+// val, whatever their scales, so 2.0 and 2.00 compare equal.
+func (b *bigDecimal) CompareTo(val *bigDecimal) types.Int {
+	if b.signum() != val.signum() {
+		if b.signum() < val.signum() {
+			return -1
+		}
+		return 1
+	}
+	if b.signum() == 0 {
+		return 0
+	}
+	return b.compareMagnitude(val) * b.signum()
+}
+
+// compareMagnitude compares |b| and |val|. The adjusted exponents
+// precision - scale decide unless they are equal, only then the unscaled
+// values are aligned, by a power of ten below either precision, so the cost
+// does not grow with the distance of the scales.
+func (b *bigDecimal) compareMagnitude(val *bigDecimal) types.Int {
+	eb, ev := b.Precision().ToLong()-b.scale.ToLong(), val.Precision().ToLong()-val.scale.ToLong()
+	if eb != ev {
+		if eb < ev {
+			return -1
+		}
+		return 1
+	}
+	x, y := b.inflated(), val.inflated()
+	if d := b.scale - val.scale; d < 0 {
+		x = x.Multiply(bigTenToThe(-d))
+	} else if d > 0 {
+		y = y.Multiply(bigTenToThe(d))
+	}
+	return x.compareMagnitute(y)
+}
+
+// Round returns b rounded to the precision of mc. It is b itself when the
+// precision is zero or not less than that of b.
+func (b *bigDecimal) Round(mc *mathContext) *bigDecimal {
+	if mc.precision == 0 {
+		return b
+	}
+	r := b
+	// a carry out of the leading digit, 9.99 to 10.0, drops one more zero
+	for r.Precision() > mc.precision {
+		drop := r.Precision() - mc.precision
+		r = r.SetScale(r.checkScale(r.scale.ToLong()-drop.ToLong()), mc.roundingMode)
+	}
+	return r
+}
+
+// DivideMathContext returns b / divisor rounded to the precision of mc. An
+// exact quotient has the preferred scale b.Scale() - divisor.Scale() as far as
+// its digits allow. With a zero precision the quotient must have a
+// terminating expansion, which its reduced denominator decides before any
+// digits are computed.
+func (b *bigDecimal) DivideMathContext(divisor *bigDecimal, mc *mathContext) *bigDecimal {
+	if divisor.signum() == 0 {
+		if b.signum() == 0 {
+			panic(errors.New("Division undefined"))
+		}
+		panic(errors.New("Division by zero"))
+	}
+	preferredScale := b.checkScale(b.scale.ToLong() - divisor.scale.ToLong())
+	if b.signum() == 0 {
+		return zeroValueOf(preferredScale)
+	}
+	if mc.precision == 0 {
+		// the quotient terminates when its reduced denominator has no prime
+		// factors but 2 and 5, which is checked before dividing anything
+		r := NewBigRationalBigDecimal(b).Divide(NewBigRationalBigDecimal(divisor))
+		m, scale := splitTwosAndFives(r.den)
+		if m.compareMagnituteLong(1) != 0 {
+			panic(errors.New("Non-terminating decimal expansion; no exact representable decimal result."))
+		}
+		q := newBigDecimalByBigInteger2(r.num.Multiply(bigTenToThe(scale)).Divide(r.den), scale)
+		if scale < preferredScale {
+			return q.SetScale(preferredScale, ROUND_UNNECESSARY)
+		}
+		return createAndStripZerosToMatchScaleByBigInteger(q.inflated(), scale, preferredScale)
+	}
+
+	// |b / divisor| lies in (10^(e-1), 10^(e+1)), so with the scale p - e the
+	// quotient has p or p + 1 digits
+	e := (b.Precision() - b.scale) - (divisor.Precision() - divisor.scale)
+	scale := b.checkScale(mc.precision.ToLong() - e.ToLong())
+	q := b.Divide(divisor, scale, mc.roundingMode)
+	if q.Precision() > mc.precision {
+		q = b.Divide(divisor, scale-1, mc.roundingMode)
+	}
+	q = q.Round(mc)
+	if q.scale > preferredScale && q.Multiply(divisor).CompareTo(b) == 0 {
+		q = createAndStripZerosToMatchScaleByBigInteger(q.inflated(), q.scale, preferredScale)
+	}
+	return q
+}
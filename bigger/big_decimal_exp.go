@@ -0,0 +1,488 @@
+package bigger
+
+import (
+	"errors"
+	"math"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// The functions below approximate their result in binary fixed point at a
+// working precision of w bits with a proven error bound, and round once both
+// ends of the bound round alike, doubling w otherwise. The results are
+// irrational apart from the exact cases each function handles first, so the
+// loop ends. exp(r) for |r| < 1 multiplies the exp of chunks of 8, 16, 32...
+// bits of r, each a short Taylor series summed by binary splitting; ln peels
+// such factors off its argument.
+
+// Exp returns e^b rounded to mc. It is exact for zero only.
+func (b *bigDecimal) Exp(mc *mathContext) *bigDecimal {
+	if b.signum() == 0 {
+		return BigDecimalValueOf(1)
+	}
+	checkInexact(mc)
+	checkExpRange(b)
+	// |e^b - 1| < 1.01 |b| < 10^-(p+1) for |b| < 10^-(p+2)
+	if p := mc.precision.ToLong(); orderOf(b) <= -(p + 2) {
+		return roundNear(BigDecimalValueOf(1), b.signum(), -(p + 1), mc)
+	}
+	return zivRound(workingBits(mc)+smallBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		return expFixed(toFixed(b, w), 1, w)
+	})
+}
+
+// Ln returns the natural logarithm of b > 0 rounded to mc. It is exact for
+// one only.
+func (b *bigDecimal) Ln(mc *mathContext) *bigDecimal {
+	if b.signum() <= 0 {
+		panic(errors.New("Logarithm of a non-positive BigDecimal"))
+	}
+	if b.CompareTo(BigDecimalValueOf(1)) == 0 {
+		return zeroValueOf(0)
+	}
+	checkInexact(mc)
+	return zivRound(workingBits(mc)+nearOneBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		l, err := lnFixed(b, w)
+		return l, BigIntegerValueOf(err.ToLong()), -w, 0
+	})
+}
+
+// Log10 returns the base 10 logarithm of b > 0 rounded to mc. It is exact
+// for the powers of ten only.
+func (b *bigDecimal) Log10(mc *mathContext) *bigDecimal {
+	if b.signum() <= 0 {
+		panic(errors.New("Logarithm of a non-positive BigDecimal"))
+	}
+	if s := stripZeros(b); s.inflated().CompareTo(ONE) == 0 {
+		return BigDecimalValueOf(-s.scale.ToLong()).Round(mc)
+	}
+	checkInexact(mc)
+	return b.Log(BigDecimalValueOf(10), mc)
+}
+
+// Log returns the logarithm of b > 0 to the base > 0, base != 1, rounded to
+// mc. A rational result p/q, log_4(8) = 3/2, is that of b^q = base^p and
+// rounds from the exact fraction; with a zero precision the result must be
+// such a fraction with a terminating expansion.
+func (b *bigDecimal) Log(base *bigDecimal, mc *mathContext) *bigDecimal {
+	if base.signum() <= 0 || base.CompareTo(BigDecimalValueOf(1)) == 0 {
+		panic(errors.New("Logarithm base not positive or one"))
+	}
+	if b.signum() <= 0 {
+		panic(errors.New("Logarithm of a non-positive BigDecimal"))
+	}
+	if b.CompareTo(BigDecimalValueOf(1)) == 0 {
+		return zeroValueOf(0)
+	}
+	w := workingBits(mc) + nearOneBits(b) + nearOneBits(base)
+	if mc.precision == 0 {
+		q, err := b.logApprox(base, w)
+		if c := b.logRational(base, q, err, w); c != nil {
+			return newBigDecimalByBigInteger2(c.num, 0).DivideMathContext(newBigDecimalByBigInteger2(c.den, 0), mc)
+		}
+		panic(errors.New("Non-terminating decimal expansion; no exact representable decimal result."))
+	}
+	for ; ; w *= 2 {
+		q, err := b.logApprox(base, w)
+		if err == nil {
+			continue
+		}
+		// a rational logarithm, which may never round apart, rounds from the
+		// fraction
+		if c := b.logRational(base, q, err, w); c != nil {
+			return newBigDecimalByBigInteger2(c.num, 0).DivideMathContext(newBigDecimalByBigInteger2(c.den, 0), mc)
+		}
+		if r := roundBracket(q, err, -w, 0, mc); r != nil {
+			return r
+		}
+	}
+}
+
+// PowDecimal returns b^y rounded to mc for a real exponent y, e^(y ln b). A
+// negative b needs an integer y. Exact powers, 4^0.5 = 2 or 1.1^3 = 1.331,
+// are computed exactly before the rounding, and so must be the power with a
+// zero precision.
+func (b *bigDecimal) PowDecimal(y *bigDecimal, mc *mathContext) *bigDecimal {
+	if y.signum() == 0 || b.CompareTo(BigDecimalValueOf(1)) == 0 {
+		return BigDecimalValueOf(1)
+	}
+	if b.signum() == 0 {
+		if y.signum() < 0 {
+			panic(errors.New("Division by zero"))
+		}
+		return zeroValueOf(0)
+	}
+	yr := NewBigRationalBigDecimal(y)
+	if b.signum() < 0 {
+		if !yr.IsInteger() {
+			panic(errors.New("Negative base with a non-integer exponent"))
+		}
+		if yr.num.getLowestSetBit() == 0 {
+			return b.Negate().PowDecimal(y, negatedContext(mc)).Negate()
+		}
+		return b.Negate().PowDecimal(y, mc)
+	}
+	if r := b.powExact(yr, mc); r != nil {
+		return r
+	}
+	checkInexact(mc)
+	// |y| < 2^(g-17) keeps y ln b within two units after the shift by g
+	g := toFixed(y.Abs(), 0).BitLength() + 17
+	return zivRound(workingBits(mc), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		l, _ := lnFixed(b, w+g)
+		t := l.Multiply(y.inflated())
+		if y.scale > 0 {
+			t, _ = floorDivide(t, bigTenToThe(y.scale))
+		} else {
+			t = bigMultiplyPowerTenByBigInteger(t, -y.scale)
+		}
+		return expFixed(t.shiftRight(g), 2, w)
+	})
+}
+
+// powExact returns b^y rounded to mc, b > 0, when b is a perfect power for
+// the denominator of y and the power has at most 4p + 50 digits, nil
+// otherwise. A longer power, or its inverse, has more than p + 1 digits and
+// is no rounding boundary.
+func (b *bigDecimal) powExact(y *bigRational, mc *mathContext) *bigDecimal {
+	x := b
+	if !y.IsInteger() {
+		// an n-th power a^n 10^-s, with a not a multiple of ten, has n <= bits
+		// of a + |s|
+		s := stripZeros(b)
+		limit := s.inflated().BitLength().ToLong() + types.Long(math.Abs(float64(s.scale))) + 1
+		if y.den.BitLength() > 31 || y.den.LongValue() > limit {
+			return nil
+		}
+		n := types.Int(y.den.LongValue())
+		r, k, exact := b.rootDigits(n, b.Precision()/n+2)
+		if !exact {
+			return nil
+		}
+		x = newBigDecimalByBigInteger2(r, k)
+	}
+	a, n := stripZeros(x), y.num.Abs()
+	if n.BitLength() > 31 || n.LongValue() > 999999999 {
+		return nil
+	}
+	if mc.precision != 0 && float64(n.LongValue())*log2Approx(a.inflated())/math.Log2(10) > float64(4*mc.precision+50) {
+		return nil
+	}
+	power := a.Pow(types.Int(n.LongValue()))
+	if y.num.signum < 0 {
+		return BigDecimalValueOf(1).DivideMathContext(power, mc)
+	}
+	return power.Round(mc)
+}
+
+// logApprox returns q with log_base(b) within (q ± err) 2^-w, err nil while
+// the precision does not tell ln(base) from zero.
+func (b *bigDecimal) logApprox(base *bigDecimal, w types.Int) (*bigInteger, *bigInteger) {
+	wl := w + 8
+	l, lerr := lnFixed(b, wl)
+	var lb *bigInteger
+	berr := types.Int(2)
+	if base.CompareTo(BigDecimalValueOf(10)) == 0 {
+		lb = lnTen.get(wl)
+	} else {
+		lb, berr = lnFixed(base, wl)
+	}
+	den := lb.Abs().add(-berr.ToLong())
+	if den.signum <= 0 {
+		return nil, nil
+	}
+	q := l.shiftLeft(w).Divide(lb)
+	// (l + a) / (lb + c) - l / lb = (a - q c 2^-w) / (lb + c)
+	err := BigIntegerValueOf(lerr.ToLong()).shiftLeft(w).Add(q.Abs().Multiply(BigIntegerValueOf(berr.ToLong())))
+	return q, err.Divide(den).add(2)
+}
+
+// logRational returns log_base(b) as the fraction p/q within the bounds of
+// the approximation with b^q = base^p, or nil. q is at most the bits of the
+// fractions of b and base.
+func (b *bigDecimal) logRational(base *bigDecimal, q, err *bigInteger, w types.Int) *bigRational {
+	if q == nil {
+		return nil
+	}
+	x, y := NewBigRationalBigDecimal(b), NewBigRationalBigDecimal(base)
+	limit := x.num.Abs().BitLength()
+	for _, v := range []*bigInteger{x.den, y.num.Abs(), y.den} {
+		if v.BitLength() > limit {
+			limit = v.BitLength()
+		}
+	}
+	c := BestApproximation(NewBigRational(q, ONE.shiftLeft(w)), BigIntegerValueOf(limit.ToLong()+1))
+	if c.num.shiftLeft(w).Subtract(q.Multiply(c.den)).Abs().CompareTo(err.Multiply(c.den)) > 0 ||
+		c.num.BitLength() > 31 || c.den.BitLength() > 31 {
+		return nil
+	}
+	if !x.Pow(types.Int(c.den.LongValue())).Equals(y.Pow(types.Int(c.num.LongValue()))) {
+		return nil
+	}
+	return c
+}
+
+// zivRound returns the rounding to mc of the value within (m ± err) 2^e2
+// 10^e10 of approx(w), doubling w from its start until both bounds round
+// alike. A nil err asks for more bits.
+func zivRound(w types.Int, mc *mathContext, approx func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int)) *bigDecimal {
+	for ; ; w *= 2 {
+		m, err, e2, e10 := approx(w)
+		if err == nil {
+			continue
+		}
+		if r := roundBracket(m, err, e2, e10, mc); r != nil {
+			return r
+		}
+	}
+}
+
+// roundBracket returns the rounding to mc of the bounds (m ± err) 2^e2 10^e10
+// when they round alike, nil otherwise.
+func roundBracket(m, err *bigInteger, e2, e10 types.Int, mc *mathContext) *bigDecimal {
+	lo := binaryToDecimal(m.Subtract(err), e2, e10).Round(mc)
+	hi := binaryToDecimal(m.Add(err), e2, e10).Round(mc)
+	if lo.CompareTo(hi) != 0 {
+		return nil
+	}
+	return lo
+}
+
+// binaryToDecimal returns v 2^e2 10^e10 exactly, 2^-n being 5^n 10^-n.
+func binaryToDecimal(v *bigInteger, e2, e10 types.Int) *bigDecimal {
+	scale := -e10.ToLong()
+	if e2 < 0 {
+		v = v.Multiply(BigIntegerValueOf(5).Pow(-e2))
+		scale -= e2.ToLong()
+	} else {
+		v = v.shiftLeft(e2)
+	}
+	d := newBigDecimalByBigInteger2(v, 0)
+	return newBigDecimalByBigInteger2(v, d.checkScale(scale))
+}
+
+// expFixed returns m, err, e2 and e10 with exp(x) within (m ± err) 2^e2
+// 10^e10 for x within (X ± xerr) 2^-w, m of about w bits.
+func expFixed(x *bigInteger, xerr, w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+	// x = k ln 10 + j ln 2 + r with |r| <= ln(2) / 2
+	ln10 := lnTen.get(w + 40)
+	k, _ := floorDivide(x.shiftLeft(41).Add(ln10), ln10.shiftLeft(1))
+	if k.BitLength() > 31 {
+		if k.signum > 0 {
+			panic(errors.New("Overflow"))
+		}
+		panic(errors.New("Underflow"))
+	}
+	kb := k.BitLength() + 2
+	r := x.Subtract(k.Multiply(lnTen.get(w + kb)).shiftRight(kb))
+	j := types.Long(math.Floor(fixedToFloat(r, w)/math.Ln2 + 0.5))
+	r = r.Subtract(BigIntegerValueOf(j).Multiply(lnTwo.get(w + 4)).shiftRight(4))
+	// r is within xerr + 4 units, e^r < 1.42
+	e, steps := expChunks(r, w)
+	err := BigIntegerValueOf((5*steps + 2*(xerr+5)).ToLong())
+	return e, err, types.Int(j) - w, types.Int(k.LongValue())
+}
+
+// expChunks returns exp(r) 2^w for r = R 2^-w, |r| < 1, and the number of
+// factors, each adding at most five units of error. The factors are the exp
+// of the chunks of 8, 16, 32... bits of r.
+func expChunks(r *bigInteger, w types.Int) (*bigInteger, types.Int) {
+	e, steps := ONE.shiftLeft(w), types.Int(0)
+	prev, prevBits := ZERO, types.Int(0)
+	for bits := types.Int(8); ; bits *= 2 {
+		if bits > w {
+			bits = w
+		}
+		part := r.shiftRight(w - bits)
+		if chunk := part.Subtract(prev.shiftLeft(bits - prevBits)); chunk.signum != 0 {
+			e = e.Multiply(expSeries(chunk, bits, w)).shiftRight(w)
+			steps++
+		}
+		if bits == w {
+			return e, steps
+		}
+		prev, prevBits = part, bits
+	}
+}
+
+// expSeries returns exp(p 2^-m) 2^w within two units for |p 2^-m| <= 2, the
+// Taylor series summed by binary splitting.
+func expSeries(p *bigInteger, m, w types.Int) *bigInteger {
+	one := ONE.shiftLeft(w)
+	if p.signum == 0 {
+		return one
+	}
+	// up to the term x^n / n! below 2^-(w+3), the rest is less still
+	lx, s, n := float64(p.BitLength()-m), 0.0, types.Int(1)
+	for {
+		s += lx - math.Log2(float64(n))
+		if n >= 4 && s < -float64(w+3) {
+			break
+		}
+		n++
+	}
+	_, q, t := seriesSplit(p, func(k types.Int) *bigInteger {
+		return BigIntegerValueOf(k.ToLong()).shiftLeft(m)
+	}, 1, n+1)
+	return one.Add(t.shiftLeft(w).Divide(q))
+}
+
+// seriesSplit returns P, Q and T for the terms k in [a, b) of a series whose
+// term k is term k-1 times p / q(k): P = p^(b-a), Q the product of the q(k)
+// and T / Q the sum of the terms relative to the term before a.
+func seriesSplit(p *bigInteger, q func(k types.Int) *bigInteger, a, b types.Int) (*bigInteger, *bigInteger, *bigInteger) {
+	if b-a == 1 {
+		return p, q(a), p
+	}
+	c := (a + b) / 2
+	p1, q1, t1 := seriesSplit(p, q, a, c)
+	p2, q2, t2 := seriesSplit(p, q, c, b)
+	return p1.Multiply(p2), q1.Multiply(q2), t1.Multiply(q2).Add(p1.Multiply(t2))
+}
+
+// lnFixed returns L and err with ln(x) within (L ± err) 2^-w for x > 0.
+func lnFixed(x *bigDecimal, w types.Int) (*bigInteger, types.Int) {
+	// x = u 10^-s = m 2^e 10^(d-s) with m = u 10^-d 2^-e near one
+	u, d := x.inflated(), x.Precision()
+	e := types.Int(math.Floor(log2Approx(u) - float64(d)*math.Log2(10) + 0.5))
+	l, steps := lnChunks(u.shiftLeft(w-e).Divide(bigTenToThe(d)), w)
+	t := BigIntegerValueOf(d.ToLong() - x.scale.ToLong())
+	tb := t.BitLength() + 2
+	l = l.Add(t.Multiply(lnTen.get(w + tb)).shiftRight(tb))
+	l = l.Add(BigIntegerValueOf(e.ToLong()).Multiply(lnTwo.get(w + 4)).shiftRight(4))
+	return l, 4*steps + 8
+}
+
+// lnChunks returns ln(m) 2^w for m = M 2^-w within a unit, m near one, and
+// the number of factors, each adding at most four units of error. It
+// divides m by exp(y) for y of 8, 16, 32... bits of ln m until one is left
+// within 2^-w, so ln m is the sum of the y plus the rest.
+func lnChunks(m *bigInteger, w types.Int) (*bigInteger, types.Int) {
+	one := ONE.shiftLeft(w)
+	y, steps := ZERO, types.Int(0)
+	peel := func(p *bigInteger, bits types.Int) {
+		if p.signum == 0 {
+			return
+		}
+		m = m.Multiply(expSeries(p.negate(), bits, w)).shiftRight(w)
+		y = y.Add(p.shiftLeft(w - bits))
+		steps++
+	}
+	peel(BigIntegerValueOf(types.Long(math.Floor(math.Log(fixedToFloat(m, w))*256))), 8)
+	// ln(1 + d) = d - d^2 / 2 within |d|^3 / 3
+	lnOnePlus := func() *bigInteger {
+		d := m.Subtract(one)
+		return d.Subtract(d.Multiply(d).shiftRight(w + 1))
+	}
+	for bits := types.Int(16); ; bits *= 2 {
+		if bits >= w {
+			return y.Add(lnOnePlus()), steps
+		}
+		peel(lnOnePlus().shiftRight(w-bits), bits)
+	}
+}
+
+// toFixed returns floor(b 2^w).
+func toFixed(b *bigDecimal, w types.Int) *bigInteger {
+	if b.scale <= 0 {
+		return bigMultiplyPowerTenByBigInteger(b.inflated(), -b.scale).shiftLeft(w)
+	}
+	q, _ := floorDivide(b.inflated().shiftLeft(w), bigTenToThe(b.scale))
+	return q
+}
+
+// fixedToFloat returns x 2^-w as a float64.
+func fixedToFloat(x *bigInteger, w types.Int) float64 {
+	shift := x.BitLength() - 60
+	if shift < 0 {
+		shift = 0
+	}
+	return math.Ldexp(float64(x.shiftRight(shift).LongValue()), int(shift-w))
+}
+
+// workingBits returns the first working precision for mc, the bits of its
+// digits and some guard bits.
+func workingBits(mc *mathContext) types.Int {
+	return mc.precision*3322/1000 + 32
+}
+
+// nearOneBits returns the bits a logarithm loses to cancellation for x near
+// one, those of the leading zeros of x - 1.
+func nearOneBits(x *bigDecimal) types.Int {
+	return smallBits(x.Subtract(BigDecimalValueOf(1)))
+}
+
+// smallBits returns the bits an absolute error bound loses on a result of
+// the size of x, those of its leading zeros after the point.
+func smallBits(x *bigDecimal) types.Int {
+	if x.signum() == 0 {
+		return 0
+	}
+	if z := x.scale - x.Precision(); z > 0 {
+		return z*3322/1000 + 4
+	}
+	return 0
+}
+
+// orderOf returns the n with 10^(n-1) <= |b| < 10^n for b != 0.
+func orderOf(b *bigDecimal) types.Long {
+	return b.Precision().ToLong() - b.scale.ToLong()
+}
+
+// roundNear returns the rounding to mc of a value strictly between x and
+// x + s 10^k, s = 1 or -1, with k <= e - p - 1 for 10^e <= |x| < 10^(e+1).
+// The rounding boundaries are among the numbers of at most p + 1 digits,
+// which lie at least 10^(e-p-1) apart around x. If x is one of them, no
+// other lies within 10^k of it and x + s 10^(k-1) rounds like the value.
+// Otherwise the value rounds like x, unless the next such number in the
+// direction s is nearer than 10^k, when roundNear returns nil.
+func roundNear(x *bigDecimal, s types.Int, k types.Long, mc *mathContext) *bigDecimal {
+	if stripZeros(x).Precision() <= mc.precision+1 {
+		return x.Add(valueOf(types.Long(s), x.checkScale(1-k))).Round(mc)
+	}
+	mode := ROUND_DOWN
+	if s == x.signum() {
+		mode = ROUND_UP
+	}
+	t := x.Round(NewMathContext(mc.precision+1, mode))
+	if t.Subtract(x).Abs().CompareTo(valueOf(1, x.checkScale(-k))) < 0 {
+		return nil
+	}
+	return x.Round(mc)
+}
+
+// stripZeros returns b without the trailing zeros of its unscaled value.
+func stripZeros(b *bigDecimal) *bigDecimal {
+	return createAndStripZerosToMatchScaleByBigInteger(b.inflated(), b.scale, MIN_INT32)
+}
+
+// negatedContext returns mc rounding -x as mc rounds x, the directed modes
+// CEILING and FLOOR swapped.
+func negatedContext(mc *mathContext) *mathContext {
+	switch mc.roundingMode {
+	case ROUND_CEILING:
+		return &mathContext{precision: mc.precision, roundingMode: ROUND_FLOOR}
+	case ROUND_FLOOR:
+		return &mathContext{precision: mc.precision, roundingMode: ROUND_CEILING}
+	}
+	return mc
+}
+
+// checkExpRange panics for |b| >= 10^10, which takes e^b beyond the range of
+// a scale.
+func checkExpRange(b *bigDecimal) {
+	if b.Precision()-b.scale > 10 {
+		if b.signum() > 0 {
+			panic(errors.New("Overflow"))
+		}
+		panic(errors.New("Underflow"))
+	}
+}
+
+// checkInexact panics for a zero precision, which asks an exact result of a
+// function found to have an irrational one.
+func checkInexact(mc *mathContext) {
+	if mc.precision == 0 {
+		panic(errors.New("Non-terminating decimal expansion; no exact representable decimal result."))
+	}
+}
@@ -0,0 +1,64 @@
+package bigger
+
+import (
+	"errors"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Pow returns b^n exactly, with the scale b.Scale() * n, for n in
+// [0, 999999999]. 0^0 is one.
+func (b *bigDecimal) Pow(n types.Int) *bigDecimal {
+	if n < 0 || n > 999999999 {
+		panic(errors.New("Invalid operation"))
+	}
+	newScale := b.checkScale(b.scale.ToLong() * n.ToLong())
+	return newBigDecimalByBigInteger2(b.inflated().Pow(n), newScale)
+}
+
+// PowMathContext returns b^n rounded to mc for n in [-999999999, 999999999],
+// by the ANSI X3.274 algorithm: the powers are squared and multiplied from
+// the top bit of |n| at the precision of mc plus the digits of |n| plus one,
+// a negative n inverts the result at that precision, and the result is then
+// rounded to mc. The exponent may have no more digits than mc. With a zero
+// precision it is Pow(n).
+func (b *bigDecimal) PowMathContext(n types.Int, mc *mathContext) *bigDecimal {
+	if mc.precision == 0 {
+		return b.Pow(n)
+	}
+	if n < -999999999 || n > 999999999 {
+		panic(errors.New("Invalid operation"))
+	}
+	if n == 0 {
+		return BigDecimalValueOf(1)
+	}
+	mag := n
+	if mag < 0 {
+		mag = -mag
+	}
+	elength := longDigitLength(mag.ToLong())
+	if elength > mc.precision {
+		panic(errors.New("Invalid operation"))
+	}
+	workmc := NewMathContext(mc.precision+elength+1, mc.roundingMode)
+	acc := BigDecimalValueOf(1)
+	seenbit := false
+	for i := 1; ; i++ {
+		// shift the next bit of the exponent into the sign
+		mag += mag
+		if mag < 0 {
+			seenbit = true
+			acc = acc.Multiply(b).Round(workmc)
+		}
+		if i == 31 {
+			break
+		}
+		if seenbit {
+			acc = acc.Multiply(acc).Round(workmc)
+		}
+	}
+	if n < 0 {
+		acc = BigDecimalValueOf(1).DivideMathContext(acc, workmc)
+	}
+	return acc.Round(mc)
+}
@@ -0,0 +1,88 @@
+package bigger
+
+import (
+	"errors"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Sqrt returns the square root of b rounded to the precision of mc. An exact
+// root, sqrt(0.25) = 0.5, has the preferred scale b.Scale() / 2 as far as its
+// digits allow. A zero precision requires the root to be exact.
+func (b *bigDecimal) Sqrt(mc *mathContext) *bigDecimal {
+	if b.signum() < 0 {
+		panic(errors.New("Attempted square root of negative BigDecimal"))
+	}
+	return b.Root(2, mc)
+}
+
+// Cbrt returns the cube root of b rounded to the precision of mc, like Root(3).
+func (b *bigDecimal) Cbrt(mc *mathContext) *bigDecimal {
+	return b.Root(3, mc)
+}
+
+// Root returns the n-th root of b rounded to the precision of mc with its
+// rounding mode. A negative b has a root for odd n only, -Root(-b). An exact
+// root has the preferred scale b.Scale() / n as far as its digits allow. A
+// zero precision requires the root to be exact.
+func (b *bigDecimal) Root(n types.Int, mc *mathContext) *bigDecimal {
+	if n <= 0 {
+		panic(errors.New("non-positive root index"))
+	}
+	if b.signum() < 0 && n%2 == 0 {
+		panic(errors.New("even root of a negative BigDecimal"))
+	}
+	if n == 1 {
+		return b.Round(mc)
+	}
+	preferredScale := b.scale / n
+	if b.signum() == 0 {
+		return zeroValueOf(preferredScale)
+	}
+	p := mc.precision
+	if p == 0 {
+		// an exact root has no more digits than this
+		p = b.Precision()/n + 2
+	}
+
+	r, k, exact := b.rootDigits(n, p)
+	if mc.precision == 0 && !exact {
+		panic(errors.New("Computed root not exact."))
+	}
+	// one sticky digit marks a non-zero remainder for the rounding
+	r = r.Multiply(TEN)
+	if !exact {
+		r = r.add(1)
+	}
+	if b.signum() < 0 {
+		r = r.negate()
+	}
+	q := newBigDecimalByBigInteger2(r, k+1).Round(mc)
+	if exact && q.scale > preferredScale {
+		q = createAndStripZerosToMatchScaleByBigInteger(q.inflated(), q.scale, preferredScale)
+	}
+	return q
+}
+
+// rootDigits returns the integer n-th root r of |b| 10^nk, of at least p + 1
+// digits, with k, and whether the root is exact.
+func (b *bigDecimal) rootDigits(n, p types.Int) (*bigInteger, types.Int, bool) {
+	// the root of u 10^-s is that of u 10^(nk-s) times 10^-k, k is chosen for
+	// an integer root of at least p + 1 digits
+	u, s := b.inflated().Abs(), b.scale
+	k := ceilDiv(s, n)
+	if d := ceilDiv(n*(p+1)-u.DigitCount(10)+s, n); d > k {
+		k = d
+	}
+	rr := bigMultiplyPowerTenByBigInteger(u, n*k-s).RootAndRemainder(n)
+	return rr[0], k, rr[1].signum == 0
+}
+
+// ceilDiv returns the least integer not below a / b for b > 0.
+func ceilDiv(a, b types.Int) types.Int {
+	q := a / b
+	if a%b > 0 {
+		q++
+	}
+	return q
+}
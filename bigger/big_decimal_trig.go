@@ -0,0 +1,459 @@
+package bigger
+
+import (
+	"errors"
+	"math"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// The functions below round like Exp and Ln, from fixed point approximations
+// with a proven error bound. sin and cos rotate by the chunks of 8, 16, 32...
+// bits of the reduced argument, the angles rotate a point onto the x axis by
+// such chunks, and the hyperbolic functions come from Exp and Ln. Angles are
+// in radians.
+
+// Sin returns the sine of b rounded to mc. It is exact for zero only.
+func (b *bigDecimal) Sin(mc *mathContext) *bigDecimal {
+	if b.signum() == 0 {
+		return zeroValueOf(0)
+	}
+	checkInexact(mc)
+	if r := roundCubic(b, -1, mc); r != nil {
+		return r
+	}
+	return zivRound(workingBits(mc)+smallBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		s, _, err := sinCosFixed(toFixed(b, w), w)
+		return s, err, -w, 0
+	})
+}
+
+// Cos returns the cosine of b rounded to mc. It is exact for zero only.
+func (b *bigDecimal) Cos(mc *mathContext) *bigDecimal {
+	if b.signum() == 0 {
+		return BigDecimalValueOf(1)
+	}
+	checkInexact(mc)
+	if r := roundSquare(b, -1, mc); r != nil {
+		return r
+	}
+	return zivRound(workingBits(mc), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		_, c, err := sinCosFixed(toFixed(b, w), w)
+		return c, err, -w, 0
+	})
+}
+
+// Tan returns the tangent of b rounded to mc. It is exact for zero only.
+func (b *bigDecimal) Tan(mc *mathContext) *bigDecimal {
+	if b.signum() == 0 {
+		return zeroValueOf(0)
+	}
+	checkInexact(mc)
+	if r := roundCubic(b, 1, mc); r != nil {
+		return r
+	}
+	return zivRound(workingBits(mc)+smallBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		s, c, err := sinCosFixed(toFixed(b, w), w)
+		q, qerr := divideFixed(s, c, err, err, w)
+		return q, qerr, -w, 0
+	})
+}
+
+// Asin returns the arcsine of b, |b| <= 1, in [-pi/2, pi/2] rounded to mc.
+// It is exact for zero only.
+func (b *bigDecimal) Asin(mc *mathContext) *bigDecimal {
+	checkUnit(b)
+	if b.signum() == 0 {
+		return zeroValueOf(0)
+	}
+	checkInexact(mc)
+	if r := roundCubic(b, 1, mc); r != nil {
+		return r
+	}
+	return zivRound(workingBits(mc)+smallBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		a, err := angleFixed(unitComplement(b, w), toFixed(b, w), w)
+		return a, err, -w, 0
+	})
+}
+
+// Acos returns the arccosine of b, |b| <= 1, in [0, pi] rounded to mc. It
+// is exact for one only.
+func (b *bigDecimal) Acos(mc *mathContext) *bigDecimal {
+	checkUnit(b)
+	if b.CompareTo(BigDecimalValueOf(1)) == 0 {
+		return zeroValueOf(0)
+	}
+	checkInexact(mc)
+	return zivRound(workingBits(mc), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		a, err := angleFixed(toFixed(b, w), unitComplement(b, w), w)
+		return a, err, -w, 0
+	})
+}
+
+// Atan returns the arctangent of b in (-pi/2, pi/2) rounded to mc. It is
+// exact for zero only.
+func (b *bigDecimal) Atan(mc *mathContext) *bigDecimal {
+	if b.signum() != 0 && mc.precision != 0 {
+		if r := roundCubic(b, -1, mc); r != nil {
+			return r
+		}
+	}
+	return b.Atan2(BigDecimalValueOf(1), mc)
+}
+
+// Atan2 returns the angle of the point (x, b) in (-pi, pi] rounded to mc,
+// the arctangent of b / x in the quadrant of the point. It is exact, zero,
+// for b = 0 and x >= 0.
+func (b *bigDecimal) Atan2(x *bigDecimal, mc *mathContext) *bigDecimal {
+	if b.signum() == 0 && x.signum() >= 0 {
+		return zeroValueOf(0)
+	}
+	checkInexact(mc)
+	// the angle of a point near the x axis is about b / x
+	ratio := types.Int(0)
+	if b.signum() != 0 && x.signum() != 0 {
+		if z := (x.Precision() - x.scale) - (b.Precision() - b.scale); z > 0 {
+			ratio = z*3322/1000 + 4
+		}
+	}
+	// b and x scaled by 2^(w-e) keep the larger within [2^(w-2), 2^w]
+	e := types.Int(math.Ceil(math.Max(decimalLog2(b), decimalLog2(x))))
+	return zivRound(workingBits(mc)+ratio, mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		a, err := angleFixed(toFixed(x, w-e), toFixed(b, w-e), w)
+		return a, err, -w, 0
+	})
+}
+
+// Sinh returns the hyperbolic sine of b rounded to mc. It is exact for zero
+// only.
+func (b *bigDecimal) Sinh(mc *mathContext) *bigDecimal {
+	if b.signum() == 0 {
+		return zeroValueOf(0)
+	}
+	if b.signum() < 0 {
+		return b.Negate().Sinh(negatedContext(mc)).Negate()
+	}
+	checkInexact(mc)
+	checkExpRange(b)
+	if r := roundCubic(b, 1, mc); r != nil {
+		return r
+	}
+	return zivRound(workingBits(mc)+smallBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		return coshSinhFixed(b, true, w)
+	})
+}
+
+// Cosh returns the hyperbolic cosine of b rounded to mc. It is exact for
+// zero only.
+func (b *bigDecimal) Cosh(mc *mathContext) *bigDecimal {
+	if b.signum() == 0 {
+		return BigDecimalValueOf(1)
+	}
+	checkInexact(mc)
+	checkExpRange(b)
+	if r := roundSquare(b, 1, mc); r != nil {
+		return r
+	}
+	b = b.Abs()
+	return zivRound(workingBits(mc), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		return coshSinhFixed(b, false, w)
+	})
+}
+
+// Tanh returns the hyperbolic tangent of b rounded to mc. It is exact for
+// zero only.
+func (b *bigDecimal) Tanh(mc *mathContext) *bigDecimal {
+	if b.signum() == 0 {
+		return zeroValueOf(0)
+	}
+	if b.signum() < 0 {
+		return b.Negate().Tanh(negatedContext(mc)).Negate()
+	}
+	checkInexact(mc)
+	// 1 - tanh b < 2e^-2b < 10^-(p+3) rounds as 1 - 10^-(p+3) does
+	if b.CompareTo(valueOf(116*(mc.precision.ToLong()+4), 2)) > 0 {
+		return BigDecimalValueOf(1).Subtract(valueOf(1, mc.precision+3)).Round(mc)
+	}
+	if r := roundCubic(b, -1, mc); r != nil {
+		return r
+	}
+	return zivRound(workingBits(mc)+smallBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		// tanh b = (1 - g) / (1 + g) with g = e^-2b, whose error counts twice
+		one := ONE.shiftLeft(w)
+		g, gerr := expAbsolute(toFixed(b, w).shiftLeft(1).negate(), 2, w)
+		return one.Subtract(g).shiftLeft(w).Divide(one.Add(g)), gerr.shiftLeft(1).add(2), -w, 0
+	})
+}
+
+// Asinh returns the inverse hyperbolic sine of b rounded to mc, the
+// logarithm of b + sqrt(b^2 + 1). It is exact for zero only.
+func (b *bigDecimal) Asinh(mc *mathContext) *bigDecimal {
+	if b.signum() == 0 {
+		return zeroValueOf(0)
+	}
+	if b.signum() < 0 {
+		return b.Negate().Asinh(negatedContext(mc)).Negate()
+	}
+	checkInexact(mc)
+	if r := roundCubic(b, -1, mc); r != nil {
+		return r
+	}
+	square := b.Multiply(b).Add(BigDecimalValueOf(1))
+	return zivRound(workingBits(mc)+smallBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		return lnAbove(toFixed(b, w).Add(toFixed(square, 2*w).Sqrt()), w)
+	})
+}
+
+// Acosh returns the inverse hyperbolic cosine of b >= 1 rounded to mc, the
+// logarithm of b + sqrt(b^2 - 1). It is exact for one only.
+func (b *bigDecimal) Acosh(mc *mathContext) *bigDecimal {
+	one := BigDecimalValueOf(1)
+	if b.CompareTo(one) < 0 {
+		panic(errors.New("Inverse hyperbolic cosine below one"))
+	}
+	if b.CompareTo(one) == 0 {
+		return zeroValueOf(0)
+	}
+	checkInexact(mc)
+	square := b.Multiply(b).Subtract(one)
+	return zivRound(workingBits(mc)+nearOneBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		return lnAbove(toFixed(b, w).Add(toFixed(square, 2*w).Sqrt()), w)
+	})
+}
+
+// Atanh returns the inverse hyperbolic tangent of b, |b| < 1, rounded to
+// mc, half the logarithm of (1 + b) / (1 - b). It is exact for zero only.
+func (b *bigDecimal) Atanh(mc *mathContext) *bigDecimal {
+	one := BigDecimalValueOf(1)
+	if b.Abs().CompareTo(one) >= 0 {
+		panic(errors.New("Inverse hyperbolic tangent of a value not within (-1, 1)"))
+	}
+	if b.signum() == 0 {
+		return zeroValueOf(0)
+	}
+	checkInexact(mc)
+	if r := roundCubic(b, 1, mc); r != nil {
+		return r
+	}
+	return zivRound(workingBits(mc)+smallBits(b), mc, func(w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+		l1, err1 := lnFixed(one.Add(b), w)
+		l2, err2 := lnFixed(one.Subtract(b), w)
+		return l1.Subtract(l2), BigIntegerValueOf((err1 + err2).ToLong()), -w - 1, 0
+	})
+}
+
+// roundCubic returns the rounding to mc of f(b) for an odd f with
+// f(b) = b + c b^3 + ..., |c| < 1 and s the sign of c, when |b| is so small
+// that b^3 no longer matters, nil otherwise.
+func roundCubic(b *bigDecimal, s types.Int, mc *mathContext) *bigDecimal {
+	// |f(b) - b| < |b|^3 < 10^(3e+3) <= 10^(e-p-1) for 10^e <= |b| < 10^(e+1)
+	e := orderOf(b) - 1
+	if p := mc.precision.ToLong(); 2*e <= -(p + 4) {
+		return roundNear(b, s*b.signum(), e-p-1, mc)
+	}
+	return nil
+}
+
+// roundSquare returns the rounding to mc of f(b) for an even f with
+// f(b) = 1 + c b^2 + ..., |c| < 1 and s the sign of c, when |b| is so small
+// that b^2 no longer matters, nil otherwise.
+func roundSquare(b *bigDecimal, s types.Int, mc *mathContext) *bigDecimal {
+	// |f(b) - 1| < b^2 < 10^(2n) <= 10^-(p+1) for |b| < 10^n
+	if p := mc.precision.ToLong(); 2*orderOf(b) <= -(p + 1) {
+		return roundNear(BigDecimalValueOf(1), s, -(p + 1), mc)
+	}
+	return nil
+}
+
+// sinCosFixed returns S, C and err with sin x and cos x within (S ± err)
+// 2^-w and (C ± err) 2^-w for x within a unit of X 2^-w.
+func sinCosFixed(x *bigInteger, w types.Int) (*bigInteger, *bigInteger, *bigInteger) {
+	// x = k pi/2 + r with |r| <= pi/4, k the floor of 2x/pi + 1/2 with pi to
+	// 40 bits more than x has
+	g := w + 40
+	if x.BitLength() > w {
+		g += x.BitLength() - w
+	}
+	pi := piConstant.get(g)
+	k, _ := floorDivide(x.shiftLeft(g-w+2).Add(pi), pi.shiftLeft(1))
+	kb := k.BitLength() + 2
+	r := x.Subtract(k.Multiply(piConstant.get(w + kb)).shiftRight(kb + 1))
+	// r is within three units
+	s, c, steps := sinCosChunks(r, w)
+	switch k.LongValue() & 3 {
+	case 1:
+		s, c = c, s.negate()
+	case 2:
+		s, c = s.negate(), c.negate()
+	case 3:
+		s, c = c.negate(), s
+	}
+	return s, c, BigIntegerValueOf((8*steps + 8).ToLong())
+}
+
+// sinCosChunks returns sin(r) 2^w and cos(r) 2^w for r = R 2^-w, |r| < 1,
+// and the number of rotations, each adding at most eight units of error.
+// The rotations are by the chunks of 8, 16, 32... bits of r.
+func sinCosChunks(r *bigInteger, w types.Int) (*bigInteger, *bigInteger, types.Int) {
+	s, c, steps := ZERO, ONE.shiftLeft(w), types.Int(0)
+	prev, prevBits := ZERO, types.Int(0)
+	for bits := types.Int(8); ; bits *= 2 {
+		if bits > w {
+			bits = w
+		}
+		part := r.shiftRight(w - bits)
+		if chunk := part.Subtract(prev.shiftLeft(bits - prevBits)); chunk.signum != 0 {
+			cs, cc := sinCosSeries(chunk, bits, w)
+			s, c = s.Multiply(cc).Add(c.Multiply(cs)).shiftRight(w), c.Multiply(cc).Subtract(s.Multiply(cs)).shiftRight(w)
+			steps++
+		}
+		if bits == w {
+			return s, c, steps
+		}
+		prev, prevBits = part, bits
+	}
+}
+
+// sinCosSeries returns sin(p 2^-m) 2^w and cos(p 2^-m) 2^w within two units
+// for |p 2^-m| <= 1, the Taylor series summed by binary splitting.
+func sinCosSeries(p *bigInteger, m, w types.Int) (*bigInteger, *bigInteger) {
+	one := ONE.shiftLeft(w)
+	// up to the terms below 2^-(w+3), x^2n / (2n+1)! for the sine
+	lx, s, n := float64(p.BitLength()-m), 0.0, types.Int(1)
+	for {
+		s += 2*lx - math.Log2(float64(2*n*(2*n+1)))
+		if n >= 2 && s+math.Log2(float64(2*n+1)) < -float64(w+3) {
+			break
+		}
+		n++
+	}
+	p2 := p.Multiply(p).negate()
+	_, qs, ts := seriesSplit(p2, func(k types.Int) *bigInteger {
+		return BigIntegerValueOf((2 * k * (2*k + 1)).ToLong()).shiftLeft(2 * m)
+	}, 1, n+1)
+	_, qc, tc := seriesSplit(p2, func(k types.Int) *bigInteger {
+		return BigIntegerValueOf(((2*k - 1) * 2 * k).ToLong()).shiftLeft(2 * m)
+	}, 1, n+1)
+	sin := p.Multiply(one.Add(ts.shiftLeft(w).Divide(qs))).shiftRight(m)
+	return sin, one.Add(tc.shiftLeft(w).Divide(qc))
+}
+
+// angleFixed returns A and err with the angle of (x, y) in (-pi, pi] within
+// (A ± err) 2^-w for x and y within a unit of X 2^-w and Y 2^-w, the larger
+// of |X| and |Y| at least 2^(w-2).
+func angleFixed(x, y *bigInteger, w types.Int) (*bigInteger, *bigInteger) {
+	// turn by pi and pi/2 to |y| <= x
+	a := ZERO
+	if x.signum < 0 {
+		if y.signum >= 0 {
+			a = piConstant.get(w)
+		} else {
+			a = piConstant.get(w).negate()
+		}
+		x, y = x.negate(), y.negate()
+	}
+	if y.CompareTo(x) > 0 {
+		x, y = y, x.negate()
+		a = a.Add(piConstant.get(w + 1).shiftRight(2))
+	} else if y.negate().CompareTo(x) > 0 {
+		x, y = y.negate(), x
+		a = a.Subtract(piConstant.get(w + 1).shiftRight(2))
+	}
+
+	steps := types.Int(0)
+	turn := func(p *bigInteger, bits types.Int) {
+		if p.signum == 0 {
+			return
+		}
+		s, c := sinCosSeries(p, bits, w)
+		x, y = x.Multiply(c).Add(y.Multiply(s)).shiftRight(w), y.Multiply(c).Subtract(x.Multiply(s)).shiftRight(w)
+		a = a.Add(p.shiftLeft(w - bits))
+		steps++
+	}
+	turn(BigIntegerValueOf(types.Long(math.Floor(math.Atan2(fixedToFloat(y, w), fixedToFloat(x, w))*256))), 8)
+	// atan t = t - t^3 / 3 within t^5 / 5
+	atan := func() *bigInteger {
+		t := y.shiftLeft(w).Divide(x)
+		return t.Subtract(t.Multiply(t).Multiply(t).shiftRight(2 * w).Divide(BigIntegerValueOf(3)))
+	}
+	for bits := types.Int(16); ; bits *= 2 {
+		if bits >= w {
+			a = a.Add(atan())
+			break
+		}
+		turn(atan().shiftRight(w-bits), bits)
+	}
+	// a unit of x or y moves the angle by 2^(w+2) / |(x, y)| <= 6 units
+	return a, BigIntegerValueOf((48*steps + 24).ToLong())
+}
+
+// divideFixed returns Q and err with n / d within (Q ± err) 2^-w for n and d
+// within nerr and derr units of N 2^-w and D 2^-w, err nil while d may be
+// zero.
+func divideFixed(n, d, nerr, derr *bigInteger, w types.Int) (*bigInteger, *bigInteger) {
+	den := d.Abs().Subtract(derr)
+	if den.signum <= 0 {
+		return ZERO, nil
+	}
+	q := n.shiftLeft(w).Divide(d)
+	// (n + a) / (d + c) - n / d = (a - q c 2^-w) / (d + c)
+	err := nerr.shiftLeft(w).Add(q.Abs().Multiply(derr))
+	return q, err.Divide(den).add(2)
+}
+
+// coshSinhFixed returns m, err, e2 and e10 with cosh b or sinh b, b > 0,
+// within (m ± err) 2^e2 10^e10, e^b (1 ± e^-2b) / 2.
+func coshSinhFixed(b *bigDecimal, sinh bool, w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+	x := toFixed(b, w)
+	m, merr, e2, e10 := expFixed(x, 1, w)
+	// e^-2b < e^-(w+4) for b >= (w+4) / 2 is left out
+	g, gerr := ZERO, ONE
+	if x.shiftRight(w).CompareTo(BigIntegerValueOf(((w + 4) / 2).ToLong())) < 0 {
+		g, gerr = expAbsolute(x.shiftLeft(1).negate(), 2, w)
+	}
+	f := ONE.shiftLeft(w).Add(g)
+	if sinh {
+		f = ONE.shiftLeft(w).Subtract(g)
+	}
+	err := merr.Multiply(f.Add(gerr)).Add(m.Multiply(gerr))
+	return m.Multiply(f), err, e2 - w - 1, e10
+}
+
+// expAbsolute returns G and err with exp(x), x <= 0, within (G ± err) 2^-w
+// for x within xerr units of X 2^-w.
+func expAbsolute(x *bigInteger, xerr, w types.Int) (*bigInteger, *bigInteger) {
+	m, err, e2, e10 := expFixed(x, xerr, w)
+	// m 2^e2 10^e10 < 4 10^e10 is below 2^-w
+	if e10 < -(w/3 + 2) {
+		return ZERO, ONE
+	}
+	ten := bigTenToThe(-e10)
+	g, _ := floorDivide(m.shiftLeft(e2+w), ten)
+	return g, err.shiftLeft(e2 + w).Divide(ten).add(2)
+}
+
+// lnAbove returns L and err with ln y within (L ± err) 2^-w for y >= 1
+// within three units of Y 2^-w.
+func lnAbove(y *bigInteger, w types.Int) (*bigInteger, *bigInteger, types.Int, types.Int) {
+	l, err := lnFixed(binaryToDecimal(y, -w, 0), w)
+	return l, BigIntegerValueOf((err + 3).ToLong()), -w, 0
+}
+
+// unitComplement returns sqrt(1 - b^2) 2^w within a unit, |b| <= 1.
+func unitComplement(b *bigDecimal, w types.Int) *bigInteger {
+	return toFixed(BigDecimalValueOf(1).Subtract(b.Multiply(b)), 2*w).Sqrt()
+}
+
+// decimalLog2 returns about log2 |b|, minus infinity for zero.
+func decimalLog2(b *bigDecimal) float64 {
+	if b.signum() == 0 {
+		return math.Inf(-1)
+	}
+	return log2Approx(b.inflated().Abs()) - float64(b.scale)*math.Log2(10)
+}
+
+func checkUnit(b *bigDecimal) {
+	if b.Abs().CompareTo(BigDecimalValueOf(1)) > 0 {
+		panic(errors.New("Inverse sine or cosine of a value not within [-1, 1]"))
+	}
+}
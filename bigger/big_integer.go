@@ -19,33 +19,36 @@ import (
 **/
 
 const (
-	MAX_INT32                              = types.Int(0x7fffffff)
-	MAX_INT64                              = types.Long(0x7fffffffffffffff)
-	MIN_INT32                              = ^MAX_INT32
-	MIN_INT64                              = ^MAX_INT64
-	pMAX_CONSTANT                          = 16
-	p_KARATSUBA_SQUARE_THRESHOLD           = 128
-	p_TOOM_COOK_SQUARE_THRESHOLD           = 216
-	p_MULTIPLY_SQUARE_THRESHOLD            = 20
-	p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD = 20
-	p_BURNIKEL_ZIEGLER_THRESHOLD           = 80
-	p_BURNIKEL_ZIEGLER_OFFSET              = 40
-	p_MAX_MAG_LENGTH                       = MAX_INT32/32 + 1
+	MAX_INT32                   = types.Int(0x7fffffff)
+	MAX_INT64                   = types.Long(0x7fffffffffffffff)
+	MIN_INT32                   = ^MAX_INT32
+	MIN_INT64                   = ^MAX_INT64
+	pMAX_CONSTANT               = 16
+	p_MULTIPLY_SQUARE_THRESHOLD = 20
+	p_MAX_MAG_LENGTH            = MAX_INT32/32 + 1
 )
 
+// algorithm crossover points in ints, see Thresholds
 var (
-	ZERO                = newBigInteger([]types.Int{0}, 0)
-	ONE, TWO, TEN       *bigInteger
-	NEGATIVE_ONE        = BigIntegerValueOf(-1)
-	p_LOG_TWO           = types.Double(math.Log(2.0))
-	p_LONG_MASK         = types.Long(0xffffffff)
-	posConst            = make([]*bigInteger, pMAX_CONSTANT+1)
-	negConst            = make([]*bigInteger, pMAX_CONSTANT+1)
-	logCache            = make([]types.Double, 32+1)
-	powerCache          = make([][]*bigInteger, 32+1)
-	zeros               = "000000000000000000000000000000000000000000000000000000000000000" // the length of zeros, length=63
-	lowestSetBitPlusTwo types.Int
-	intRadix            = []types.Int{0, 0,
+	p_KARATSUBA_SQUARE_THRESHOLD           types.Int = 128
+	p_TOOM_COOK_SQUARE_THRESHOLD           types.Int = 216
+	p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD types.Int = 20
+	p_BURNIKEL_ZIEGLER_THRESHOLD           types.Int = 80
+	p_BURNIKEL_ZIEGLER_OFFSET              types.Int = 40
+)
+
+var (
+	ZERO          = newBigInteger([]types.Int{}, 0)
+	ONE, TWO, TEN *bigInteger
+	NEGATIVE_ONE  *bigInteger
+	p_LOG_TWO     = types.Double(math.Log(2.0))
+	p_LONG_MASK   = types.Long(0xffffffff)
+	posConst      = make([]*bigInteger, pMAX_CONSTANT+1)
+	negConst      = make([]*bigInteger, pMAX_CONSTANT+1)
+	logCache      = make([]types.Double, 32+1)
+	powerCache    = make([][]*bigInteger, 32+1)
+	zeros         = "000000000000000000000000000000000000000000000000000000000000000" // the length of zeros, length=63
+	intRadix      = []types.Int{0, 0,
 		0x40000000, 0x4546b3db, 0x40000000, 0x48c27395, 0x159fd800,
 		0x75db9c97, 0x40000000, 0x17179149, 0x3b9aca00, 0xcc6db61,
 		0x19a10000, 0x309f1021, 0x57f6c100, 0xa2f1b6f, 0x10000000,
@@ -91,6 +94,7 @@ type bigInteger struct {
 	mag                       []types.Int // order
 	firstNonzeroIntNumPlusTwo types.Int
 	bitLengthPlusOne          types.Int
+	lowestSetBitPlusTwo       types.Int
 }
 
 func init() {
@@ -99,6 +103,9 @@ func init() {
 
 var once sync.Once
 
+// powerCacheLock guards powerCache, the radix conversion halves may run in parallel
+var powerCacheLock sync.Mutex
+
 func Init() {
 	once.Do(func() {
 		for i := types.Int(1); i <= pMAX_CONSTANT; i++ {
@@ -120,6 +127,7 @@ func Init() {
 		ONE = BigIntegerValueOf(1)
 		TWO = BigIntegerValueOf(2)
 		TEN = BigIntegerValueOf(10)
+		NEGATIVE_ONE = BigIntegerValueOf(-1)
 	})
 }
 
@@ -154,7 +162,7 @@ func subtract2(val types.Long, little []types.Int) []types.Int {
 	} else {
 		result := make([]types.Int, 2)
 		if len(little) == 1 {
-			difference := (val.ToInt().ToLong() & p_LONG_MASK) - (little[0].ToLong() - p_LONG_MASK)
+			difference := (val.ToInt().ToLong() & p_LONG_MASK) - (little[0].ToLong() & p_LONG_MASK)
 			result[1] = difference.ToInt()
 			borrow := (difference >> 32) != 0
 			if borrow {
@@ -195,7 +203,7 @@ func subtract(big []types.Int, val types.Long) []types.Int {
 	borrow := (difference >> 32) != 0
 	for bigIndex > 0 && borrow {
 		bigIndex--
-		result[bigIndex] -= 1
+		result[bigIndex] = big[bigIndex] - 1
 		borrow = result[bigIndex] == -1
 	}
 
@@ -301,7 +309,7 @@ func newBigInteger(magnitude []types.Int, signum types.Int) *bigInteger {
 func bitCount(i types.Int) types.Int {
 	i = i - (i.ShiftR(1) & 0x55555555)
 	i = (i & 0x33333333) + (i.ShiftR(2) & 0x33333333)
-	i = i + (i.ShiftR(4))&0x0f0f0f0f
+	i = (i + i.ShiftR(4)) & 0x0f0f0f0f
 	i = i + i.ShiftR(8)
 	i = i + i.ShiftR(16)
 	return i & 0x3f
@@ -314,10 +322,10 @@ func bitLengthForInt(n types.Int) types.Int {
 /*
 toString Converts the specified bigInteger to a string and appends to buf.
 */
-func toString(u *bigInteger, buf *bytes.Buffer, radix types.Int, digits types.Int) {
+func toString(u *bigInteger, buf *bytes.Buffer, radix types.Int, digits types.Int, ws *workspace) {
 
-	if len(u.mag) <= p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD {
-		s := u.smallToString(radix)
+	if types.Int(len(u.mag)) <= p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD {
+		s := u.smallToString(radix, ws)
 
 		if (types.Int(len(s)) < digits) && buf.Len() > 0 {
 			for i := types.Int(len(s)); i < digits; i++ {
@@ -332,20 +340,31 @@ func toString(u *bigInteger, buf *bytes.Buffer, radix types.Int, digits types.In
 	b = u.BitLength()
 
 	// Calculate a value for n in the equation radix^(2^n) = u
-	n = types.Int(math.Round(math.Log(float64(types.Double(b)*p_LOG_TWO))/float64(p_LOG_TWO) - 1.0))
+	n = types.Int(math.Round(math.Log(float64(types.Double(b)*p_LOG_TWO/logCache[radix]))/float64(p_LOG_TWO) - 1.0))
 	v := getRadixConversionCache(radix, n)
 	var result []*bigInteger
-	result = u.DivideAndRemainder(v)
+	result = u.divideAndRemainder(v, ws)
 
 	expectedDigits := types.Int(1 << n)
 
-	toString(result[0], buf, radix, digits-expectedDigits)
-	toString(result[1], buf, radix, expectedDigits)
+	// the lower half is converted on its own and zero padded to its width,
+	// a fresh buffer does not know that it is not the leading part
+	var low bytes.Buffer
+	// a workspace serves a single goroutine, a forked half uses the shared pools
+	join := fork(types.Int(len(u.mag)), func() { toString(result[1], &low, radix, expectedDigits, nil) })
+	toString(result[0], buf, radix, digits-expectedDigits, ws)
+	join()
+	for i := types.Int(low.Len()); i < expectedDigits; i++ {
+		buf.WriteString("0")
+	}
+	buf.Write(low.Bytes())
 }
 
 // getRadixConversionCache
 // Returns the value radix^(2^exponent) from cache. If this value not exist, it is added.
 func getRadixConversionCache(radix types.Int, exponent types.Int) *bigInteger {
+	powerCacheLock.Lock()
+	defer powerCacheLock.Unlock()
 	cacheLine := powerCache[radix]
 	if exponent < types.Int(len(cacheLine)) {
 		return cacheLine[exponent]
@@ -364,7 +383,7 @@ func getRadixConversionCache(radix types.Int, exponent types.Int) *bigInteger {
 }
 
 func (bi *bigInteger) getLowestSetBit() types.Int {
-	lsb := lowestSetBitPlusTwo - 2
+	lsb := bi.lowestSetBitPlusTwo - 2
 	if lsb == -2 { // lsb not initialized yet
 		lsb = 0
 		if bi.signum == 0 {
@@ -379,7 +398,7 @@ func (bi *bigInteger) getLowestSetBit() types.Int {
 			}
 			lsb += (i << 5) + NumberOfTrailingZeros(b)
 		}
-		lowestSetBitPlusTwo = lsb + 2
+		bi.lowestSetBitPlusTwo = lsb + 2
 	}
 	return lsb
 }
@@ -465,7 +484,7 @@ func add_(x []types.Int, val types.Long) []types.Int {
 
 	for xIndex > 0 {
 		xIndex--
-		result[xIndex] = x[xIndex] + 1
+		result[xIndex] = x[xIndex]
 	}
 
 	if carry {
@@ -540,8 +559,8 @@ func (b *bigInteger) Pow(exponent types.Int) *bigInteger {
 
 	partToSquare := b.Abs()
 	powersOfTwo := partToSquare.getLowestSetBit()
-	bitsToShiftLong := (powersOfTwo * exponent).ToLong()
-	if bitsToShiftLong > p_LONG_MASK {
+	bitsToShiftLong := powersOfTwo.ToLong() * exponent.ToLong()
+	if bitsToShiftLong > MAX_INT32.ToLong() {
 		panic(errors.New("overflow"))
 	}
 	bitsToShift := bitsToShiftLong.ToInt()
@@ -568,7 +587,7 @@ func (b *bigInteger) Pow(exponent types.Int) *bigInteger {
 		}
 	}
 
-	scaleFactor := (remainingBits * exponent).ToLong()
+	scaleFactor := remainingBits.ToLong() * exponent.ToLong()
 	if len(partToSquare.mag) == 1 && scaleFactor <= 62 {
 		var newSign types.Int
 		if b.signum < 0 && (exponent&1) == 1 {
@@ -688,9 +707,7 @@ func (b *bigInteger) shiftRightImpl(n types.Int) *bigInteger {
 		}
 	}
 	if nBits == 0 {
-		newMagLen := magLen - nInts
-		copy(newMag, b.mag)
-		newMag = tool.Copy(newMag, newMagLen)
+		newMag = tool.Copy(b.mag, magLen-nInts)
 	} else {
 		i := 0
 		highBits := b.mag[0].ShiftR(nBits)
@@ -764,6 +781,9 @@ func (b *bigInteger) squareRec(isRecursion bool) *bigInteger {
 					panic(errors.New("overflow"))
 				}
 			}
+			if length >= p_FFT_SQUARE_THRESHOLD {
+				return b.squareNTT()
+			}
 			return b.squareToomCook3()
 		}
 	}
@@ -780,13 +800,19 @@ func (b *bigInteger) squareToomCook3() *bigInteger {
 	a0 = b.getToomSlice(k, r, 2, length)
 	var v0, v1, v2, vm1, vinf, t1, t2, tm1, da1 *bigInteger
 
-	v0 = a0.squareRec(true)
+	join0 := fork(length, func() { v0 = a0.squareRec(true) })
 	da1 = a2.Add(a0)
-	vm1 = da1.Subtract(a1).squareRec(true)
+	dm1 := da1.Subtract(a1)
+	joinm1 := fork(length, func() { vm1 = dm1.squareRec(true) })
 	da1 = da1.Add(a1)
-	v1 = da1.squareRec(true)
-	vinf = a2.squareRec(true)
+	d1 := da1
+	join1 := fork(length, func() { v1 = d1.squareRec(true) })
+	joininf := fork(length, func() { vinf = a2.squareRec(true) })
 	v2 = da1.Add(a2).shiftLeft(1).Subtract(a0).squareRec(true)
+	join0()
+	joinm1()
+	join1()
+	joininf()
 
 	t2 = v2.Subtract(vm1).exactDivideBy3()
 	tm1 = v1.Subtract(vm1).shiftRight(1)
@@ -805,10 +831,14 @@ func (b *bigInteger) squareKaratsuba() *bigInteger {
 
 	xl := b.getLower(half)
 	xh := b.getUpper(half)
-	xhs := xh.square() // xhs = xh ^ 2
-	xls := xl.square() // xls = xl ^ 2
+	var xhs, xls *bigInteger
+	join1 := fork(half*2, func() { xhs = xh.square() }) // xhs = xh ^ 2
+	join2 := fork(half*2, func() { xls = xl.square() }) // xls = xl ^ 2
+	mid := xl.Add(xh).square()
+	join1()
+	join2()
 
-	return xhs.shiftLeft(half * 32).Add(xl.Add(xh).square().Subtract(xhs.Add(xls))).shiftLeft(half * 32).Add(xls)
+	return xhs.shiftLeft(half * 32).Add(mid.Subtract(xhs.Add(xls))).shiftLeft(half * 32).Add(xls)
 }
 
 func (b *bigInteger) getLower(n types.Int) *bigInteger {
@@ -936,37 +966,36 @@ func (b *bigInteger) multiplyRec(val *bigInteger, isRecursion bool) *bigInteger
 					panic("overflow")
 				}
 			}
-
+			if xlen >= p_FFT_MULTIPLY_THRESHOLD && ylen >= p_FFT_MULTIPLY_THRESHOLD {
+				return multiplyNTT(b, val)
+			}
 			return multiplyToomCook3(b, val)
 		}
 	}
 
 }
 
-func (b *bigInteger) smallToString(radix types.Int) string {
+func (b *bigInteger) smallToString(radix types.Int, ws *workspace) string {
 	if b.signum == 0 {
 		return "0"
 	}
 
 	maxNumDigitGroups := (4*len(b.mag) + 6) / 7
 	digitGroup := make([]string, maxNumDigitGroups)
-	tmp := b.Abs()
+	d := longRadix[radix].LongValue()
+	// the quotient of one step is the dividend of the next one
+	tmp := newMutableBigIntegerArray(ws.getInts(types.Int(len(b.mag))))
+	copy(tmp.value, b.mag)
+	q := newMutableBigIntegerArray(ws.getInts(types.Int(len(b.mag))))
 	numGroups := 0
-	for tmp.signum != 0 {
-		d := longRadix[radix]
-
-		q, a, b2 := newMutableBigIntegerDefault(),
-			newMutableBigIntegerArray(tmp.mag),
-			newMutableBigIntegerArray(d.mag)
-
-		r := a.Divide(b2, q)
-		q2 := q.toBigInteger(tmp.signum * d.signum)
-		r2 := r.toBigInteger(tmp.signum * d.signum)
-
-		digitGroup[numGroups] = strconv.FormatInt(int64(r2.LongValue()), int(radix))
+	for !tmp.IsZero() {
+		r := tmp.divide(d, q)
+		digitGroup[numGroups] = strconv.FormatInt(int64(r), int(radix))
 		numGroups++
-		tmp = q2
+		tmp, q = q, tmp
 	}
+	ws.putInts(tmp.value)
+	ws.putInts(q.value)
 
 	var buf bytes.Buffer
 	if b.signum < 0 {
@@ -983,24 +1012,25 @@ func (b *bigInteger) smallToString(radix types.Int) string {
 	return buf.String()
 }
 
-func (b *bigInteger) divideAndRemainderKnuth(val *bigInteger) []*bigInteger {
+func (b *bigInteger) divideAndRemainderKnuth(val *bigInteger, ws *workspace) []*bigInteger {
 	result := make([]*bigInteger, 2)
 	q := newMutableBigIntegerDefault()
-	a := newMutableBigIntegerArray(b.mag)
+	a := ws.mutable(b)
 	bb := newMutableBigIntegerArray(val.mag)
 	r := a.divideKnuth(bb, q, true)
 	if b.signum == val.signum {
 		result[0] = q.toBigInteger(1)
 	} else {
-		result[0] = q.toBigInteger(0)
+		result[0] = q.toBigInteger(-1)
 	}
 	result[1] = r.toBigInteger(b.signum)
 	return result
 }
 
-func (b *bigInteger) divideAndRemainderBurnikelZiegler(val *bigInteger) []*bigInteger {
+func (b *bigInteger) divideAndRemainderBurnikelZiegler(val *bigInteger, ws *workspace) []*bigInteger {
 	q := newMutableBigIntegerDefault()
-	r := newMutableBigIntegerByBigInteger(b).DivideAndRemainderBurnikelZiegler(newMutableBigIntegerByBigInteger(val), q)
+	// both operands are only read, the division copies them into scratch
+	r := ws.mutable(b).DivideAndRemainderBurnikelZiegler(ws.mutable(val), q)
 	var qBigInt, rBigInt *bigInteger
 	if q.IsZero() {
 		qBigInt = ZERO
@@ -1093,16 +1123,16 @@ func (b *bigInteger) compareMagnitute(val *bigInteger) types.Int {
 	return 0
 }
 
-func (b *bigInteger) divideKnuth(val *bigInteger) *bigInteger {
+func (b *bigInteger) divideKnuth(val *bigInteger, ws *workspace) *bigInteger {
 	q := newMutableBigIntegerDefault()
-	a := newMutableBigIntegerArray(b.mag)
+	a := ws.mutable(b)
 	b2 := newMutableBigIntegerArray(val.mag)
 	a.divideKnuth(b2, q, false)
 	return q.toBigInteger(b.signum * val.signum)
 }
 
-func (b *bigInteger) divideBurnikelZiegler(val *bigInteger) *bigInteger {
-	return b.divideAndRemainderBurnikelZiegler(val)[0]
+func (b *bigInteger) divideBurnikelZiegler(val *bigInteger, ws *workspace) *bigInteger {
+	return b.divideAndRemainderBurnikelZiegler(val, ws)[0]
 }
 
 func (b *bigInteger) Subtract(val *bigInteger) *bigInteger {
@@ -1147,19 +1177,27 @@ func (b *bigInteger) LongValue() types.Long {
 }
 
 func (b *bigInteger) DivideAndRemainder(val *bigInteger) []*bigInteger {
-	if len(val.mag) < p_BURNIKEL_ZIEGLER_THRESHOLD || len(b.mag)-len(val.mag) < p_BURNIKEL_ZIEGLER_OFFSET {
-		return b.divideAndRemainderKnuth(val)
+	return b.divideAndRemainder(val, nil)
+}
+
+func (b *bigInteger) divideAndRemainder(val *bigInteger, ws *workspace) []*bigInteger {
+	if types.Int(len(val.mag)) < p_BURNIKEL_ZIEGLER_THRESHOLD || types.Int(len(b.mag)-len(val.mag)) < p_BURNIKEL_ZIEGLER_OFFSET {
+		return b.divideAndRemainderKnuth(val, ws)
 	} else {
-		return b.divideAndRemainderBurnikelZiegler(val)
+		return b.divideAndRemainderBurnikelZiegler(val, ws)
 	}
 }
 
 func (b *bigInteger) Divide(val *bigInteger) *bigInteger {
-	if len(val.mag) < p_BURNIKEL_ZIEGLER_THRESHOLD ||
-		len(b.mag)-len(val.mag) < p_BURNIKEL_ZIEGLER_OFFSET {
-		return b.divideKnuth(val)
+	return b.divide(val, nil)
+}
+
+func (b *bigInteger) divide(val *bigInteger, ws *workspace) *bigInteger {
+	if types.Int(len(val.mag)) < p_BURNIKEL_ZIEGLER_THRESHOLD ||
+		types.Int(len(b.mag)-len(val.mag)) < p_BURNIKEL_ZIEGLER_OFFSET {
+		return b.divideKnuth(val, ws)
 	} else {
-		return b.divideBurnikelZiegler(val)
+		return b.divideBurnikelZiegler(val, ws)
 	}
 }
 
@@ -1168,7 +1206,11 @@ func (b *bigInteger) Sqrt() *bigInteger {
 		panic(errors.New("negative BigIntager"))
 	}
 
-	return newMutableBigIntegerArray(b.mag).sqrt().ToBigIntegerDefault()
+	return b.sqrt(nil)
+}
+
+func (b *bigInteger) sqrt(ws *workspace) *bigInteger {
+	return ws.mutable(b).sqrt().ToBigIntegerDefault()
 }
 
 // LongValueExact this bigInteger converted to a long. different from LongValue, this func will throw panic error
@@ -1205,16 +1247,22 @@ func multiplyToomCook3(a *bigInteger, b *bigInteger) *bigInteger {
 	b0 = b.getToomSlice(k, r, 2, largest)
 
 	var v0, v1, v2, vm1, vinf, t1, t2, tm1, da1, db1 *bigInteger
-	v0 = a0.multiplyRec(b0, true)
+	join0 := fork(largest, func() { v0 = a0.multiplyRec(b0, true) })
 	da1 = a2.Add(a0)
 	db1 = b2.Add(b0)
-	vm1 = da1.Subtract(a1).multiplyRec(db1.Subtract(b1), true)
+	dm1, em1 := da1.Subtract(a1), db1.Subtract(b1)
+	joinm1 := fork(largest, func() { vm1 = dm1.multiplyRec(em1, true) })
 	da1 = da1.Add(a1)
 	db1 = db1.Add(b1)
-	v1 = da1.multiplyRec(db1, true)
-	v2 = da1.Add(a2).shiftLeft(1).Subtract(a0).multiplyRec(
-		db1.Add(b2).shiftLeft(1).Subtract(b0), true)
+	d1, e1 := da1, db1
+	join1 := fork(largest, func() { v1 = d1.multiplyRec(e1, true) })
+	d2, e2 := da1.Add(a2).shiftLeft(1).Subtract(a0), db1.Add(b2).shiftLeft(1).Subtract(b0)
+	join2 := fork(largest, func() { v2 = d2.multiplyRec(e2, true) })
 	vinf = a2.multiplyRec(b2, true)
+	join0()
+	joinm1()
+	join1()
+	join2()
 
 	t2 = v2.Subtract(vm1).exactDivideBy3()
 	tm1 = v1.Subtract(vm1).shiftRight(1)
@@ -1244,11 +1292,15 @@ func multiplyKaratsuba(x *bigInteger, y *bigInteger) *bigInteger {
 	yl := y.getLower(half)
 	yh := y.getUpper(half)
 
-	p1 := xh.Multiply(yh) // p1 = xh*yh
-	p2 := xl.Multiply(yl) // p2 = xl*yl
+	var p1, p2, p3 *bigInteger
+	size := types.Int(math.Max(float64(xlen), float64(ylen)))
+	join1 := fork(size, func() { p1 = xh.Multiply(yh) }) // p1 = xh*yh
+	join2 := fork(size, func() { p2 = xl.Multiply(yl) }) // p2 = xl*yl
 
 	// p3=(xh+xl)*(yh+yl)
-	p3 := xh.Add(xl).Multiply(yh.Add(yl))
+	p3 = xh.Add(xl).Multiply(yh.Add(yl))
+	join1()
+	join2()
 
 	// result = p1 * 2^(32*2*half) + (p3 - p1 - p2) * 2^(32*half) + p2
 	result := p1.shiftLeft(32 * half).Add(p3.Subtract(p1).Subtract(p2)).shiftLeft(32 * half).Add(p2)
@@ -1439,8 +1491,7 @@ func addOne(a []types.Int, offset types.Int, mlen types.Int, carry types.Int) ty
 	if t.ShiftR(32) == 0 {
 		return 0
 	}
-	for mlen-1 > 0 {
-		mlen--
+	for mlen--; mlen >= 0; mlen-- {
 		offset--
 		if offset < 0 {
 			return 1
@@ -1503,8 +1554,8 @@ func implSquareToLenChecks(x []types.Int, length types.Int, z []types.Int, zlen
 	if zlen < 1 {
 		panic(errors.New(fmt.Sprintf("invalid input length: %d", zlen)))
 	}
-	if zlen < types.Int(len(z)) {
-		panic(errors.New(fmt.Sprintf("input length out of bound: %d > %d", length, len(z))))
+	if zlen > types.Int(len(z)) {
+		panic(errors.New(fmt.Sprintf("input length out of bound: %d > %d", zlen, len(z))))
 	}
 }
 
@@ -1716,6 +1767,11 @@ func NewBigIntegerString(val string) *bigInteger {
 	return NewBigIntegerStringRadix(val, 10)
 }
 
+// BigIntegers collects bigIntegers into a slice, e.g. the residues and moduli of CRT
+func BigIntegers(values ...*bigInteger) []*bigInteger {
+	return append([]*bigInteger(nil), values...)
+}
+
 func NewBigIntegerBytes(val []byte) *bigInteger {
 	if len(val) == 0 {
 		panic(errors.New("zero length"))
@@ -1857,6 +1913,10 @@ func (b *bigInteger) String() string {
 }
 
 func (b *bigInteger) StringRadix(radix types.Int) string {
+	return b.stringRadix(radix, nil)
+}
+
+func (b *bigInteger) stringRadix(radix types.Int, ws *workspace) string {
 	if b.signum == 0 {
 		return "0"
 	}
@@ -1864,16 +1924,16 @@ func (b *bigInteger) StringRadix(radix types.Int) string {
 		radix = 10
 	}
 
-	if len(b.mag) <= p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD {
-		return b.smallToString(radix)
+	if types.Int(len(b.mag)) <= p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD {
+		return b.smallToString(radix, ws)
 	}
 
 	var buf bytes.Buffer
 	if b.signum < 0 {
-		toString(b.negate(), &buf, radix, 0)
+		toString(b.negate(), &buf, radix, 0, ws)
 		return "-" + buf.String()
 	} else {
-		toString(b, &buf, radix, 0)
+		toString(b, &buf, radix, 0, ws)
 	}
 	return buf.String()
 }
@@ -1882,7 +1942,7 @@ func (b *bigInteger) StringRadix(radix types.Int) string {
 func (b *bigInteger) BitLength() types.Int {
 	n := b.bitLengthPlusOne - 1
 	if n == -1 {
-		var m []types.Int
+		m := b.mag
 		length := types.Int(len(m))
 		if length == 0 {
 			n = 0
@@ -1959,8 +2019,8 @@ func (b *bigInteger) add(val types.Long) *bigInteger {
 	if b.signum == 0 {
 		return BigIntegerValueOf(val)
 	}
-	if (val>>63)|(val.ShiftR(63)) == b.signum.ToLong() {
-		return newBigInteger(add_(b.mag, types.Long(math.Abs(float64(val)))), b.signum)
+	if val.Signum() == b.signum {
+		return newBigInteger(add_(b.mag, val.Abs()), b.signum)
 	}
 	cmp := b.compareMagnituteLong(val)
 	if cmp == 0 {
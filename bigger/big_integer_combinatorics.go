@@ -0,0 +1,270 @@
+package bigger
+
+import (
+	"errors"
+	"math/bits"
+	"sort"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Combinatorial functions.
+//
+// Factorials are computed by Luschny's prime swing: the odd part of n! is
+// the square of the odd part of (n/2)! times the odd part of the swing
+// n!/((n/2)!)^2, whose prime factorization is read off directly. Binomials
+// and double factorials are likewise assembled from their factorization. All
+// products are evaluated by binary splitting, so that the multiplications
+// work on operands of similar size and profit from Toom-Cook and the NTT.
+
+// Factorial returns n!.
+func Factorial(n types.Int) *bigInteger {
+	if n < 0 {
+		panic(errors.New("negative factorial argument"))
+	}
+	if n < 2 {
+		return ONE
+	}
+	primes := primesUpTo(n)
+	return oddFactorial(n, primes).shiftLeft(n - types.Int(bits.OnesCount32(uint32(n))))
+}
+
+// oddFactorial returns the odd part of n!, primes holds at least the primes <= n.
+func oddFactorial(n types.Int, primes []types.Int) *bigInteger {
+	if n < 3 {
+		return ONE
+	}
+	swing := primePowerProduct(primesBelow(primes, n)[1:], func(p types.Int) types.Int {
+		e := types.Int(0)
+		for q := n / p; q > 0; q /= p {
+			e += q & 1
+		}
+		return e
+	})
+	return oddFactorial(n/2, primes).square().Multiply(swing)
+}
+
+// DoubleFactorial returns n!! = n (n-2) (n-4) ... down to 1 or 2.
+func DoubleFactorial(n types.Int) *bigInteger {
+	if n < 0 {
+		panic(errors.New("negative factorial argument"))
+	}
+	m := n / 2
+	if n%2 == 0 {
+		return Factorial(m).shiftLeft(m)
+	}
+	if n < 3 {
+		return ONE
+	}
+	// (2m+1)!! = (2m+1)! / (2^m m!)
+	return primePowerProduct(primesUpTo(n)[1:], func(p types.Int) types.Int {
+		return legendre(n, p) - legendre(m, p)
+	})
+}
+
+// Primorial returns the product of all primes <= n.
+func Primorial(n types.Int) *bigInteger {
+	return primePowerProduct(primesUpTo(n), func(types.Int) types.Int { return 1 })
+}
+
+// Binomial returns the binomial coefficient n over k, which is zero for k < 0
+// and k > n.
+func Binomial(n, k types.Int) *bigInteger {
+	if n < 0 {
+		panic(errors.New("negative binomial argument"))
+	}
+	if k < 0 || k > n {
+		return ZERO
+	}
+	if k > n-k {
+		k = n - k
+	}
+	if k == 0 {
+		return ONE
+	}
+	if k.ToLong()*16 < n.ToLong() {
+		// a few factors, sieving up to n would dominate
+		return rangeProduct((n - k + 1).ToLong(), n.ToLong()).Divide(Factorial(k))
+	}
+	// Kummer: the exponent of p is the number of borrows subtracting k from n in base p
+	return primePowerProduct(primesUpTo(n), func(p types.Int) types.Int {
+		return legendre(n, p) - legendre(k, p) - legendre(n-k, p)
+	})
+}
+
+// Multinomial returns (k1 + k2 + ... + km)! / (k1! k2! ... km!).
+func Multinomial(ks ...types.Int) *bigInteger {
+	r, n := ONE, types.Int(0)
+	for _, k := range ks {
+		if k < 0 {
+			panic(errors.New("negative multinomial argument"))
+		}
+		if n += k; n < 0 {
+			panic(errors.New("overflow"))
+		}
+		r = r.Multiply(Binomial(n, k))
+	}
+	return r
+}
+
+// Fibonacci returns the n-th Fibonacci number, F(0) = 0, F(1) = 1, extended
+// to negative n by F(-n) = (-1)^(n+1) F(n).
+func Fibonacci(n types.Int) *bigInteger {
+	if n < 0 {
+		f := Fibonacci(-n)
+		if n%2 == 0 {
+			return f.negate()
+		}
+		return f
+	}
+	return fibonacciPair(n)[0]
+}
+
+// Lucas returns the n-th Lucas number, L(0) = 2, L(1) = 1, extended to
+// negative n by L(-n) = (-1)^n L(n).
+func Lucas(n types.Int) *bigInteger {
+	if n < 0 {
+		l := Lucas(-n)
+		if n%2 != 0 {
+			return l.negate()
+		}
+		return l
+	}
+	// L(n) = 2 F(n+1) - F(n)
+	f := fibonacciPair(n)
+	return f[1].shiftLeft(1).Subtract(f[0])
+}
+
+// fibonacciPair returns F(n) and F(n+1) by the doubling formulas
+// F(2k) = F(k) (2 F(k+1) - F(k)) and F(2k+1) = F(k)^2 + F(k+1)^2.
+func fibonacciPair(n types.Int) [2]*bigInteger {
+	a, b := ZERO, ONE
+	for i := bits.Len32(uint32(n)) - 1; i >= 0; i-- {
+		c := a.Multiply(b.shiftLeft(1).Subtract(a))
+		d := a.square().Add(b.square())
+		if n>>uint(i)&1 == 0 {
+			a, b = c, d
+		} else {
+			a, b = d, c.Add(d)
+		}
+	}
+	return [2]*bigInteger{a, b}
+}
+
+// Catalan returns the n-th Catalan number (2n over n) / (n+1).
+func Catalan(n types.Int) *bigInteger {
+	if n < 0 {
+		panic(errors.New("negative catalan argument"))
+	}
+	return Binomial(2*n, n).Divide(BigIntegerValueOf((n + 1).ToLong()))
+}
+
+// Stirling2 returns the Stirling number of the second kind S(n, k), the
+// number of partitions of n elements into k non-empty blocks, by
+// k! S(n, k) = sum (-1)^j (k over j) (k-j)^n.
+func Stirling2(n, k types.Int) *bigInteger {
+	if n < 0 || k < 0 {
+		panic(errors.New("negative stirling argument"))
+	}
+	if k > n {
+		return ZERO
+	}
+	if k == 0 {
+		if n == 0 {
+			return ONE
+		}
+		return ZERO
+	}
+	sum, c := ZERO, ONE
+	for j := types.Int(0); j < k; j++ {
+		t := c.Multiply(BigIntegerValueOf((k - j).ToLong()).Pow(n))
+		if j%2 == 0 {
+			sum = sum.Add(t)
+		} else {
+			sum = sum.Subtract(t)
+		}
+		c = c.multiplyLong((k - j).ToLong()).Divide(BigIntegerValueOf((j + 1).ToLong()))
+	}
+	return sum.Divide(Factorial(k))
+}
+
+// Bell returns the n-th Bell number, the number of partitions of n elements,
+// by the Bell triangle.
+func Bell(n types.Int) *bigInteger {
+	if n < 0 {
+		panic(errors.New("negative bell argument"))
+	}
+	row := []*bigInteger{ONE}
+	for i := types.Int(0); i < n; i++ {
+		next := make([]*bigInteger, len(row)+1)
+		next[0] = row[len(row)-1]
+		for j, v := range row {
+			next[j+1] = next[j].Add(v)
+		}
+		row = next
+	}
+	return row[0]
+}
+
+// legendre returns the exponent of the prime p in n!.
+func legendre(n, p types.Int) types.Int {
+	e := types.Int(0)
+	for q := n / p; q > 0; q /= p {
+		e += q
+	}
+	return e
+}
+
+// primesBelow returns the prefix of the ascending primes that are <= n.
+func primesBelow(primes []types.Int, n types.Int) []types.Int {
+	return primes[:sort.Search(len(primes), func(i int) bool { return primes[i] > n })]
+}
+
+// primePowerProduct returns the product of p^exponent(p) over the primes.
+// Small prime powers are packed into longs before the binary splitting.
+func primePowerProduct(primes []types.Int, exponent func(p types.Int) types.Int) *bigInteger {
+	var factors []*bigInteger
+	acc := uint64(1)
+	for _, p := range primes {
+		e := exponent(p)
+		for ; e > 0; e-- {
+			hi, lo := bits.Mul64(acc, uint64(p))
+			if hi != 0 || lo > uint64(MAX_INT64) {
+				if e > 1 {
+					factors = append(factors, BigIntegerValueOf(p.ToLong()).Pow(e))
+					break
+				}
+				factors = append(factors, BigIntegerValueOf(types.Long(acc)))
+				lo = uint64(p)
+			}
+			acc = lo
+		}
+	}
+	factors = append(factors, BigIntegerValueOf(types.Long(acc)))
+	return productTree(factors)
+}
+
+// rangeProduct returns lo * (lo+1) * ... * hi for 0 < lo <= hi.
+func rangeProduct(lo, hi types.Long) *bigInteger {
+	if hi-lo < 8 {
+		r := BigIntegerValueOf(lo)
+		for i := lo + 1; i <= hi; i++ {
+			r = r.multiplyLong(i)
+		}
+		return r
+	}
+	mid := lo + (hi-lo)/2
+	return rangeProduct(lo, mid).Multiply(rangeProduct(mid+1, hi))
+}
+
+// productTree returns the product of the factors by binary splitting.
+func productTree(factors []*bigInteger) *bigInteger {
+	switch len(factors) {
+	case 0:
+		return ONE
+	case 1:
+		return factors[0]
+	}
+	mid := len(factors) / 2
+	return productTree(factors[:mid]).Multiply(productTree(factors[mid:]))
+}
@@ -0,0 +1,101 @@
+package bigger
+
+import (
+	"errors"
+)
+
+// ErrInconsistentCongruences is returned by CRT when no integer satisfies all
+// the congruences.
+var ErrInconsistentCongruences = errors.New("inconsistent congruences")
+
+// CRT solves the simultaneous congruences x = residues[i] mod moduli[i]. The
+// moduli must be positive, they need not be pairwise coprime. It returns the
+// solution 0 <= x < m and the combined modulus m, the least common multiple of
+// the moduli, or ErrInconsistentCongruences when there is no solution.
+func CRT(residues, moduli []*bigInteger) (x, m *bigInteger, err error) {
+	if len(residues) != len(moduli) {
+		panic(errors.New("residues and moduli differ in length"))
+	}
+	x, m = ZERO, ONE
+	for i, mi := range moduli {
+		if mi.signum <= 0 {
+			panic(errors.New("BigInteger: modulus not positive"))
+		}
+		// x + m t = a mod mi  <=>  (m/g) t = (a - x)/g mod mi/g, g = gcd(m, mi)
+		a := residues[i].Mod(mi)
+		g := m.Gcd(mi)
+		qr := a.Subtract(x).DivideAndRemainder(g)
+		if qr[1].signum != 0 {
+			return nil, nil, ErrInconsistentCongruences
+		}
+		mg := mi.Divide(g)
+		t := qr[0].Multiply(m.Divide(g).ModInverse(mg)).Mod(mg)
+		x = x.Add(m.Multiply(t))
+		m = m.Multiply(mg)
+	}
+	return x, m, nil
+}
+
+// crtBasis is a set of pairwise coprime moduli with the constants of Garner's
+// algorithm, for repeated conversions between residues and integers.
+type crtBasis struct {
+	moduli  []*bigInteger
+	inverse [][]*bigInteger // inverse[i][j] = moduli[j]^-1 mod moduli[i] for j < i
+	modulus *bigInteger     // the product of the moduli
+}
+
+// NewCRTBasis precomputes Garner's algorithm for the positive, pairwise coprime
+// moduli. It panics when two moduli have a common factor.
+func NewCRTBasis(moduli []*bigInteger) *crtBasis {
+	c := &crtBasis{moduli: append([]*bigInteger(nil), moduli...), inverse: make([][]*bigInteger, len(moduli)), modulus: ONE}
+	for i, mi := range c.moduli {
+		if mi.signum <= 0 {
+			panic(errors.New("BigInteger: modulus not positive"))
+		}
+		c.inverse[i] = make([]*bigInteger, i)
+		for j, mj := range c.moduli[:i] {
+			if mj.Gcd(mi).compareMagnituteLong(1) != 0 {
+				panic(errors.New("moduli are not pairwise coprime"))
+			}
+			c.inverse[i][j] = mj.ModInverse(mi)
+		}
+		c.modulus = c.modulus.Multiply(mi)
+	}
+	return c
+}
+
+// Modulus returns the product of the moduli.
+func (c *crtBasis) Modulus() *bigInteger {
+	return c.modulus
+}
+
+// Combine returns the unique 0 <= x < Modulus() with x = residues[i] mod
+// moduli[i].
+func (c *crtBasis) Combine(residues []*bigInteger) *bigInteger {
+	if len(residues) != len(c.moduli) {
+		panic(errors.New("residues and moduli differ in length"))
+	}
+	// the mixed radix digits, x = v0 + v1 m0 + v2 m0 m1 + ...
+	v := make([]*bigInteger, len(c.moduli))
+	for i, mi := range c.moduli {
+		t := residues[i]
+		for j := 0; j < i; j++ {
+			t = t.Subtract(v[j]).Multiply(c.inverse[i][j]).Mod(mi)
+		}
+		v[i] = t.Mod(mi)
+	}
+	x := ZERO
+	for i := len(v) - 1; i >= 0; i-- {
+		x = x.Multiply(c.moduli[i]).Add(v[i])
+	}
+	return x
+}
+
+// Residues returns x mod moduli[i] for every modulus.
+func (c *crtBasis) Residues(x *bigInteger) []*bigInteger {
+	r := make([]*bigInteger, len(c.moduli))
+	for i, mi := range c.moduli {
+		r[i] = x.Mod(mi)
+	}
+	return r
+}
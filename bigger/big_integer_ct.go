@@ -0,0 +1,269 @@
+package bigger
+
+import (
+	"errors"
+
+	"github.com/sineycoder/go-bigger/tool"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// fixedMagnitude is a non-negative integer held in a fixed number of ints,
+// big-endian like mag. Its operations take time depending on that length only,
+// never on the value.
+type fixedMagnitude struct {
+	value []types.Int
+}
+
+// NewFixedMagnitude returns x in the given number of ints. It panics when x is
+// negative or does not fit.
+func NewFixedMagnitude(x *bigInteger, ints types.Int) *fixedMagnitude {
+	if x.signum < 0 {
+		panic(errors.New("negative magnitude"))
+	}
+	if types.Int(len(x.mag)) > ints {
+		panic(errors.New("magnitude does not fit"))
+	}
+	f := &fixedMagnitude{value: make([]types.Int, ints)}
+	copy(f.value[ints-types.Int(len(x.mag)):], x.mag)
+	return f
+}
+
+// Len returns the length in ints.
+func (f *fixedMagnitude) Len() types.Int {
+	return types.Int(len(f.value))
+}
+
+// BigInteger returns the value as a bigInteger.
+func (f *fixedMagnitude) BigInteger() *bigInteger {
+	mag := trustedStripLeadingZeroInts(f.value)
+	return newBigInteger(append([]types.Int(nil), mag...), 1)
+}
+
+// Select sets f to x if cond is 1 and to y if cond is 0.
+func (f *fixedMagnitude) Select(cond types.Int, x, y *fixedMagnitude) *fixedMagnitude {
+	f.checkLength(x)
+	f.checkLength(y)
+	mask := -(cond & 1)
+	for i := range f.value {
+		f.value[i] = y.value[i] ^ (mask & (x.value[i] ^ y.value[i]))
+	}
+	return f
+}
+
+// Swap exchanges the values of f and g if cond is 1 and leaves both if it is 0.
+func (f *fixedMagnitude) Swap(cond types.Int, g *fixedMagnitude) {
+	f.checkLength(g)
+	mask := -(cond & 1)
+	for i := range f.value {
+		t := mask & (f.value[i] ^ g.value[i])
+		f.value[i] ^= t
+		g.value[i] ^= t
+	}
+}
+
+// Compare returns -1, 0 or 1 as f is less than, equal to or greater than g.
+func (f *fixedMagnitude) Compare(g *fixedMagnitude) types.Int {
+	f.checkLength(g)
+	return ctCompareMagnitude(f.value, g.value)
+}
+
+// Equal reports whether f and g hold the same value.
+func (f *fixedMagnitude) Equal(g *fixedMagnitude) bool {
+	return f.Compare(g) == 0
+}
+
+func (f *fixedMagnitude) checkLength(g *fixedMagnitude) {
+	if len(f.value) != len(g.value) {
+		panic(errors.New("fixed magnitudes differ in length"))
+	}
+}
+
+// CompareCT compares x and y like CompareTo, in a time that depends on the
+// lengths of their magnitudes but not on their values. Use fixedMagnitude to
+// hide the lengths as well.
+func CompareCT(x, y *bigInteger) types.Int {
+	n := tool.MaxInt(types.Int(len(x.mag)), types.Int(len(y.mag)))
+	c := ctCompareMagnitude(NewFixedMagnitude(x.Abs(), n).value, NewFixedMagnitude(y.Abs(), n).value)
+	// the signs decide when they differ, otherwise the magnitudes do
+	d := x.signum - y.signum
+	s := d>>31 | types.Int(uint32(-d)>>31)
+	return s + (1-s*s)*x.signum*c
+}
+
+// EqualCT reports whether x and y are equal, in the time of CompareCT.
+func EqualCT(x, y *bigInteger) bool {
+	return CompareCT(x, y) == 0
+}
+
+// ModPowCT returns this^exponent mod m like ModPow, for a secret exponent of
+// at most the bit length of m, as ModPowCTBits does with that bit count.
+func (b *bigInteger) ModPowCT(exponent, m *bigInteger) *bigInteger {
+	return b.ModPowCTBits(exponent, m, m.BitLength())
+}
+
+// ModPowCTBits returns this^exponent mod m like ModPow, for a secret exponent
+// of at most bits bits. The modulus must be odd and the exponent
+// non-negative. A fixed 4-bit window runs over all bits of the bit count,
+// whatever the length of the exponent, and every table entry is read, so
+// the sequence of operations and memory accesses depends on bits and the
+// length of m only, never on the values of the base, the exponent or m.
+// Outside of that are the checks of the arguments, the reduction of a base
+// outside [0, m) and the conversion of the result to a bigInteger, which
+// strips its leading zero ints.
+func (b *bigInteger) ModPowCTBits(exponent, m *bigInteger, bits types.Int) *bigInteger {
+	if m.signum <= 0 || !m.testBit(0) {
+		panic(errors.New("BigInteger: modulus not odd"))
+	}
+	if exponent.signum < 0 {
+		panic(errors.New("BigInteger: negative exponent"))
+	}
+	if exponent.BitLength() > bits {
+		panic(errors.New("BigInteger: exponent longer than the bit count"))
+	}
+	if m.compareMagnituteLong(1) == 0 {
+		return ZERO
+	}
+	base := b
+	if base.signum < 0 || base.CompareTo(m) >= 0 {
+		base = base.Mod(m)
+	}
+	n := types.Int(len(m.mag))
+	mont := newMontgomery(m)
+	scratch := mont.scratch()
+	x := mont.toMontgomery(NewFixedMagnitude(base, n).value, scratch)
+
+	// table[i] = x^i in Montgomery form
+	var table [16][]types.Int
+	table[0] = mont.toMontgomery(NewFixedMagnitude(ONE, n).value, scratch)
+	for i := 1; i < len(table); i++ {
+		table[i] = make([]types.Int, n)
+		mont.multiply(table[i-1], x, table[i], scratch)
+	}
+	acc, t := append([]types.Int(nil), table[0]...), make([]types.Int, n)
+	entry := make([]types.Int, n)
+	e := NewFixedMagnitude(exponent, (bits+31)/32).value
+	for i := (bits+3)/4 - 1; i >= 0; i-- {
+		for k := 0; k < 4; k++ {
+			mont.multiply(acc, acc, t, scratch)
+			acc, t = t, acc
+		}
+		w := (uint32(e[types.Int(len(e))-1-i/8]) >> uint(4*(i%8))) & 15
+		for j := range entry {
+			entry[j] = 0
+		}
+		for k := range table {
+			mask := -types.Int(ctEqual(uint32(k), w))
+			for j := range entry {
+				entry[j] |= mask & table[k][j]
+			}
+		}
+		mont.multiply(acc, entry, t, scratch)
+		acc, t = t, acc
+	}
+	return (&fixedMagnitude{value: mont.fromMontgomery(acc, scratch)}).BigInteger()
+}
+
+// montgomery multiplies modulo an odd m in Montgomery form, x R mod m with
+// R = 2^(32 len(m.mag)), with a fixed sequence of operations.
+type montgomery struct {
+	modulus []types.Int
+	inverse uint32      // -m^-1 mod 2^32
+	rr      []types.Int // R^2 mod m
+}
+
+func newMontgomery(m *bigInteger) *montgomery {
+	n := types.Int(len(m.mag))
+	m0 := uint32(m.mag[n-1])
+	// Newton's iteration doubles the correct low bits of the inverse each step
+	inv := m0
+	for i := 0; i < 4; i++ {
+		inv *= 2 - m0*inv
+	}
+	rr := ONE.shiftLeft(64 * n).Mod(m)
+	return &montgomery{
+		modulus: m.mag,
+		inverse: -inv,
+		rr:      NewFixedMagnitude(rr, n).value,
+	}
+}
+
+// scratch returns the work space of multiply.
+func (mt *montgomery) scratch() []uint32 {
+	return make([]uint32, len(mt.modulus)+2)
+}
+
+func (mt *montgomery) toMontgomery(x []types.Int, t []uint32) []types.Int {
+	z := make([]types.Int, len(x))
+	mt.multiply(x, mt.rr, z, t)
+	return z
+}
+
+func (mt *montgomery) fromMontgomery(x []types.Int, t []uint32) []types.Int {
+	one := make([]types.Int, len(x))
+	one[len(one)-1] = 1
+	z := make([]types.Int, len(x))
+	mt.multiply(x, one, z, t)
+	return z
+}
+
+// multiply sets z to x y R^-1 mod m for x, y < m, by word-wise interleaved
+// multiplication and reduction followed by one masked subtraction of m. t is
+// the scratch space.
+func (mt *montgomery) multiply(x, y, z []types.Int, t []uint32) {
+	n := len(mt.modulus)
+	word := func(a []types.Int, i int) uint64 {
+		return uint64(uint32(a[n-1-i]))
+	}
+	for i := range t {
+		t[i] = 0
+	}
+	for i := 0; i < n; i++ {
+		xi := word(x, i)
+		var c uint64
+		for j := 0; j < n; j++ {
+			s := uint64(t[j]) + xi*word(y, j) + c
+			t[j], c = uint32(s), s>>32
+		}
+		s := uint64(t[n]) + c
+		t[n], t[n+1] = uint32(s), uint32(s>>32)
+
+		q := uint64(t[0] * mt.inverse)
+		c = (uint64(t[0]) + q*word(mt.modulus, 0)) >> 32
+		for j := 1; j < n; j++ {
+			s := uint64(t[j]) + q*word(mt.modulus, j) + c
+			t[j-1], c = uint32(s), s>>32
+		}
+		s = uint64(t[n]) + c
+		t[n-1] = uint32(s)
+		t[n] = t[n+1] + uint32(s>>32)
+	}
+	// t < 2m, subtract m unless that borrows past t[n]
+	var borrow uint64
+	for j := 0; j < n; j++ {
+		d := uint64(t[j]) - word(mt.modulus, j) - borrow
+		z[n-1-j], borrow = types.Int(uint32(d)), d>>63
+	}
+	keep := -types.Int(uint32(borrow) & ^t[n] & 1)
+	for j := 0; j < n; j++ {
+		z[n-1-j] = (keep & types.Int(t[j])) | (^keep & z[n-1-j])
+	}
+}
+
+// ctCompareMagnitude compares two magnitudes of equal length without
+// branching on their values.
+func ctCompareMagnitude(x, y []types.Int) types.Int {
+	var lt, gt uint32
+	for i := len(x) - 1; i >= 0; i-- {
+		a, b := uint64(uint32(x[i])), uint64(uint32(y[i]))
+		eq := ctEqual(uint32(a), uint32(b))
+		lt = uint32((a-b)>>63) | (eq & lt)
+		gt = uint32((b-a)>>63) | (eq & gt)
+	}
+	return types.Int(gt) - types.Int(lt)
+}
+
+// ctEqual returns 1 if a == b and 0 otherwise.
+func ctEqual(a, b uint32) uint32 {
+	x := a ^ b
+	return ((x | -x) >> 31) ^ 1
+}
@@ -0,0 +1,412 @@
+package bigger
+
+import (
+	"context"
+	"errors"
+	"math/bits"
+	"math/rand"
+	"sort"
+
+	"github.com/sineycoder/go-bigger/tool"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Integer factorization.
+//
+// Factors below p_TRIAL_DIVISION_LIMIT are removed by trial division. A
+// composite cofactor is split by Pollard-Brent rho, which finds factors of up
+// to about 10 digits quickly, and then by Lenstra's elliptic curve method on
+// Montgomery curves with Suyama's parametrization and growing smoothness
+// bounds, which finds factors of 15 to 30 digits. The second ECM stage is the
+// baby step giant step continuation. The parts are tested for primality and
+// perfect powers and split recursively.
+
+const (
+	// p_TRIAL_DIVISION_LIMIT bounds the primes removed by trial division
+	p_TRIAL_DIVISION_LIMIT = 4096
+	// p_FACTOR_CERTAINTY is the certainty of the primality tests of factors
+	p_FACTOR_CERTAINTY = 100
+	// p_RHO_ITERATIONS bounds the iterations of a single rho attempt
+	p_RHO_ITERATIONS = 1 << 16
+	// p_ECM_D is the giant step of the ECM stage 2
+	p_ECM_D = 210
+	// p_ECM_B2_FACTOR is the ratio of the ECM stage 2 and stage 1 bounds
+	p_ECM_B2_FACTOR = 50
+)
+
+var (
+	p_TRIAL_PRIMES = primesUpTo(p_TRIAL_DIVISION_LIMIT)
+	// p_ECM_SCHEDULE holds smoothness bounds and the number of curves tried
+	// with each, the usual choice for factors of 15, 20, 25 and 30 digits
+	p_ECM_SCHEDULE = [][2]types.Int{{2000, 25}, {11000, 90}, {50000, 300}, {250000, 700}}
+)
+
+// PrimeFactor is a prime and its multiplicity.
+type PrimeFactor struct {
+	Prime    *bigInteger
+	Exponent types.Int
+}
+
+// Factor returns the prime factorization of |n| as prime to exponent pairs
+// in ascending order of the primes, bigIntegers are no usable map keys. The
+// factorization of 1 is empty. The primes are probable primes with certainty
+// 100. Factor returns the context error when ctx is done before the
+// factorization is complete, large numbers without small factors cannot be
+// factored in reasonable time.
+func Factor(ctx context.Context, n *bigInteger) ([]PrimeFactor, error) {
+	if n.signum == 0 {
+		panic(errors.New("factorization of zero"))
+	}
+	f := &factorizer{ctx: ctx, r: rand.New(rand.NewSource(1))}
+	x := n.Abs()
+	for _, p := range p_TRIAL_PRIMES {
+		if x.compareMagnituteLong(p.ToLong()*p.ToLong()) < 0 {
+			break
+		}
+		if x.remainderInt(p) == 0 {
+			bp, e := BigIntegerValueOf(p.ToLong()), types.Int(0)
+			for ; x.remainderInt(p) == 0; e++ {
+				x = x.Divide(bp)
+			}
+			f.factors = append(f.factors, PrimeFactor{bp, e})
+		}
+	}
+	if x.compareMagnituteLong(p_TRIAL_DIVISION_LIMIT*p_TRIAL_DIVISION_LIMIT) < 0 {
+		// no factor below the limit is left, what remains is 1 or a prime
+		if x.compareMagnituteLong(1) > 0 {
+			f.factors = append(f.factors, PrimeFactor{x, 1})
+		}
+	} else if err := f.factor(x, 1); err != nil {
+		return nil, err
+	}
+	return f.merge(), nil
+}
+
+type factorizer struct {
+	ctx     context.Context
+	r       *rand.Rand
+	factors []PrimeFactor
+}
+
+// factor adds the factorization of n^e, n > 1 has no factors below the trial
+// division limit.
+func (f *factorizer) factor(n *bigInteger, e types.Int) error {
+	if n.IsProbablePrime(p_FACTOR_CERTAINTY) {
+		f.factors = append(f.factors, PrimeFactor{n, e})
+		return nil
+	}
+	if r, k, ok := n.IsPerfectPower(); ok {
+		return f.factor(r, e*k)
+	}
+	d, err := f.split(n)
+	if err != nil {
+		return err
+	}
+	if err = f.factor(d, e); err != nil {
+		return err
+	}
+	return f.factor(n.Divide(d), e)
+}
+
+// merge sorts the factors and adds up the exponents of equal primes.
+func (f *factorizer) merge() []PrimeFactor {
+	sort.Slice(f.factors, func(i, j int) bool {
+		return f.factors[i].Prime.CompareTo(f.factors[j].Prime) < 0
+	})
+	result := make([]PrimeFactor, 0, len(f.factors))
+	for _, pf := range f.factors {
+		if k := len(result) - 1; k >= 0 && result[k].Prime.CompareTo(pf.Prime) == 0 {
+			result[k].Exponent += pf.Exponent
+		} else {
+			result = append(result, pf)
+		}
+	}
+	return result
+}
+
+// split returns a non-trivial factor of the composite n.
+func (f *factorizer) split(n *bigInteger) (*bigInteger, error) {
+	if d, err := f.rho(n, 1); d != nil || err != nil {
+		return d, err
+	}
+	for i := 0; ; i++ {
+		// factors beyond 30 digits, keep trying the largest bound until ctx is done
+		stage := p_ECM_SCHEDULE[tool.MinInt(types.Int(i), types.Int(len(p_ECM_SCHEDULE)-1))]
+		bounds := newEcmBounds(stage[0])
+		for k := types.Int(0); k < stage[1]; k++ {
+			if d, err := f.ecm(n, bounds); d != nil || err != nil {
+				return d, err
+			}
+		}
+	}
+}
+
+// rho runs Pollard's rho with Brent's cycle detection on x^2 + c. It returns
+// nil when no factor is found within p_RHO_ITERATIONS.
+func (f *factorizer) rho(n *bigInteger, c types.Long) (*bigInteger, error) {
+	const m = 128 // gcds are batched over m steps
+	next := func(y *bigInteger) *bigInteger {
+		return y.square().add(c).Mod(n)
+	}
+	y := f.random(n)
+	g, q := ONE, ONE
+	var x, ys *bigInteger
+	for r := 1; g.compareMagnituteLong(1) == 0; r *= 2 {
+		if r > p_RHO_ITERATIONS {
+			return nil, nil
+		}
+		if err := f.ctx.Err(); err != nil {
+			return nil, err
+		}
+		x = y
+		for i := 0; i < r; i++ {
+			y = next(y)
+		}
+		for k := 0; k < r && g.compareMagnituteLong(1) == 0; k += m {
+			ys = y
+			for i := 0; i < m && i < r-k; i++ {
+				y = next(y)
+				q = q.Multiply(x.Subtract(y)).Mod(n)
+			}
+			g = q.Gcd(n)
+		}
+	}
+	if g.CompareTo(n) == 0 {
+		// the batch overshot, redo it step by step
+		for g = ONE; g.compareMagnituteLong(1) == 0; {
+			ys = next(ys)
+			g = x.Subtract(ys).Gcd(n)
+		}
+	}
+	if g.CompareTo(n) == 0 {
+		return nil, nil
+	}
+	return g, nil
+}
+
+// ecmBounds holds the smoothness bounds of both ECM stages and the primes
+// below them.
+type ecmBounds struct {
+	b1, b2  types.Int
+	primes  []types.Int // the primes <= b1
+	isPrime []bool      // primality of all values <= b2 + p_ECM_D
+}
+
+func newEcmBounds(b1 types.Int) *ecmBounds {
+	b2 := b1 * p_ECM_B2_FACTOR
+	isPrime := make([]bool, b2+p_ECM_D+1)
+	for _, p := range primesUpTo(b2 + p_ECM_D) {
+		isPrime[p] = true
+	}
+	return &ecmBounds{b1: b1, b2: b2, primes: primesBelow(primesUpTo(b2), b1), isPrime: isPrime}
+}
+
+// ecm runs the elliptic curve method on a random curve. It returns nil when
+// the curve does not reveal a factor.
+func (f *factorizer) ecm(n *bigInteger, bounds *ecmBounds) (*bigInteger, error) {
+	if err := f.ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Suyama: u = sigma^2 - 5, v = 4 sigma, the point (u^3 : v^3) lies on the
+	// curve with (A + 2) / 4 = (v - u)^3 (3u + v) / (16 u^3 v)
+	sigma := f.random(n.add(-6)).add(6)
+	u := sigma.square().add(-5).Mod(n)
+	v := sigma.shiftLeft(2).Mod(n)
+	u3 := u.square().Multiply(u).Mod(n)
+	den := u3.Multiply(v).shiftLeft(4).Mod(n)
+	if g := den.Gcd(n); g.compareMagnituteLong(1) != 0 {
+		if g.CompareTo(n) == 0 {
+			return nil, nil
+		}
+		return g, nil
+	}
+	vu := v.Subtract(u)
+	num := vu.square().Multiply(vu).Mod(n).Multiply(u.multiplyLong(3).Add(v)).Mod(n)
+	c := &montgomeryCurve{n: n, a24: num.Multiply(den.ModInverse(n)).Mod(n)}
+
+	// stage 1 multiplies by every prime power up to b1
+	x, z := u3, v.square().Multiply(v).Mod(n)
+	for i, p := range bounds.primes {
+		q := p.ToLong()
+		for q*p.ToLong() <= bounds.b1.ToLong() {
+			q *= p.ToLong()
+		}
+		x, z = c.multiply(x, z, q)
+		if i%1024 == 1023 {
+			if err := f.ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	g := z.Gcd(n)
+	if g.compareMagnituteLong(1) != 0 {
+		if g.CompareTo(n) == 0 {
+			return nil, nil
+		}
+		return g, nil
+	}
+
+	// stage 2 looks for a single prime q = mD +- j in (b1, b2]. With the baby
+	// steps jQ and the giant steps mDQ, mDQ = +-jQ on the curve modulo the
+	// factor exactly when X(mDQ) Z(jQ) - X(jQ) Z(mDQ) vanishes modulo it.
+	const d = p_ECM_D
+	bx, bz := make([]*bigInteger, d/2), make([]*bigInteger, d/2)
+	bx[1], bz[1] = x, z
+	x2, z2 := c.double(x, z)
+	bx[3], bz[3] = c.add(x2, z2, x, z, x, z)
+	for j := 5; j < d/2; j += 2 {
+		bx[j], bz[j] = c.add(bx[j-2], bz[j-2], x2, z2, bx[j-4], bz[j-4])
+	}
+	dx, dz := c.multiply(x, z, d)
+	// R = mDQ and P = (m-1)DQ, 0Q is the point at infinity which the
+	// differential addition cannot take, so m starts at 2
+	m := tool.MaxInt(bounds.b1/d, 2)
+	rx, rz := c.multiply(x, z, m.ToLong()*d)
+	px, pz := c.multiply(x, z, (m.ToLong()-1)*d)
+	acc := ONE
+	for ; m*d-d/2 <= bounds.b2; m++ {
+		for j := types.Int(1); j < d/2; j += 2 {
+			if bounds.isPrime[m*d+j] || bounds.isPrime[m*d-j] {
+				acc = acc.Multiply(rx.Multiply(bz[j]).Subtract(bx[j].Multiply(rz))).Mod(n)
+			}
+		}
+		nx, nz := c.add(rx, rz, dx, dz, px, pz)
+		rx, rz, px, pz = nx, nz, rx, rz
+		if m%64 == 0 {
+			if err := f.ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if g := acc.Gcd(n); g.compareMagnituteLong(1) != 0 && g.CompareTo(n) != 0 {
+		return g, nil
+	}
+	return nil, nil
+}
+
+// random returns a uniformly distributed value in [0, n) for n > 0.
+func (f *factorizer) random(n *bigInteger) *bigInteger {
+	mag := make([]types.Int, len(n.mag)+1)
+	for i := range mag {
+		mag[i] = types.Int(f.r.Uint32())
+	}
+	return newBigInteger(trustedStripLeadingZeroInts(mag), 1).Mod(n)
+}
+
+// montgomeryCurve is a curve B y^2 = x^3 + A x^2 + x modulo n, points are kept
+// as (X : Z) without the y coordinate.
+type montgomeryCurve struct {
+	n   *bigInteger
+	a24 *bigInteger // (A + 2) / 4
+}
+
+func (c *montgomeryCurve) double(x, z *bigInteger) (*bigInteger, *bigInteger) {
+	s := x.Add(z).square().Mod(c.n)
+	d := x.Subtract(z).square().Mod(c.n)
+	t := s.Subtract(d)
+	return s.Multiply(d).Mod(c.n), t.Multiply(d.Add(c.a24.Multiply(t))).Mod(c.n)
+}
+
+// add returns P + Q given P - Q.
+func (c *montgomeryCurve) add(xp, zp, xq, zq, xd, zd *bigInteger) (*bigInteger, *bigInteger) {
+	u := xp.Subtract(zp).Multiply(xq.Add(zq))
+	v := xp.Add(zp).Multiply(xq.Subtract(zq))
+	return zd.Multiply(u.Add(v).Mod(c.n).square()).Mod(c.n), xd.Multiply(u.Subtract(v).Mod(c.n).square()).Mod(c.n)
+}
+
+// multiply returns k P by the Montgomery ladder, k > 0.
+func (c *montgomeryCurve) multiply(x, z *bigInteger, k types.Long) (*bigInteger, *bigInteger) {
+	x0, z0 := x, z
+	x1, z1 := c.double(x, z)
+	for i := bits.Len64(uint64(k)) - 2; i >= 0; i-- {
+		if k>>uint(i)&1 == 1 {
+			x0, z0 = c.add(x1, z1, x0, z0, x, z)
+			x1, z1 = c.double(x1, z1)
+		} else {
+			x1, z1 = c.add(x1, z1, x0, z0, x, z)
+			x0, z0 = c.double(x0, z0)
+		}
+	}
+	return x0, z0
+}
+
+// EulerPhi returns the number of integers in [1, n] coprime to n, n > 0.
+func EulerPhi(ctx context.Context, n *bigInteger) (*bigInteger, error) {
+	factors, err := factorPositive(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	phi := ONE
+	for _, pf := range factors {
+		phi = phi.Multiply(pf.Prime.Pow(pf.Exponent - 1).Multiply(pf.Prime.add(-1)))
+	}
+	return phi, nil
+}
+
+// Moebius returns the Moebius function of n > 0: 0 if n has a square factor,
+// otherwise -1 or 1 as the number of its prime factors is odd or even.
+func Moebius(ctx context.Context, n *bigInteger) (types.Int, error) {
+	factors, err := factorPositive(ctx, n)
+	if err != nil {
+		return 0, err
+	}
+	mu := types.Int(1)
+	for _, pf := range factors {
+		if pf.Exponent > 1 {
+			return 0, nil
+		}
+		mu = -mu
+	}
+	return mu, nil
+}
+
+// Divisors returns all positive divisors of n > 0 in ascending order.
+func Divisors(ctx context.Context, n *bigInteger) ([]*bigInteger, error) {
+	factors, err := factorPositive(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	divisors := []*bigInteger{ONE}
+	for _, pf := range factors {
+		count := len(divisors)
+		pk := ONE
+		for k := types.Int(0); k < pf.Exponent; k++ {
+			pk = pk.Multiply(pf.Prime)
+			for _, d := range divisors[:count] {
+				divisors = append(divisors, d.Multiply(pk))
+			}
+		}
+	}
+	sort.Slice(divisors, func(i, j int) bool { return divisors[i].CompareTo(divisors[j]) < 0 })
+	return divisors, nil
+}
+
+// SigmaK returns the sum of the k-th powers of the positive divisors of n > 0,
+// k >= 0. SigmaK(n, 0) is the number of divisors.
+func SigmaK(ctx context.Context, n *bigInteger, k types.Int) (*bigInteger, error) {
+	if k < 0 {
+		panic(errors.New("negative divisor power"))
+	}
+	factors, err := factorPositive(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	sigma := ONE
+	for _, pf := range factors {
+		if k == 0 {
+			sigma = sigma.multiplyLong((pf.Exponent + 1).ToLong())
+			continue
+		}
+		// 1 + p^k + ... + p^(ek) = (p^(k(e+1)) - 1) / (p^k - 1)
+		pk := pf.Prime.Pow(k)
+		sigma = sigma.Multiply(pk.Pow(pf.Exponent + 1).add(-1).Divide(pk.add(-1)))
+	}
+	return sigma, nil
+}
+
+func factorPositive(ctx context.Context, n *bigInteger) ([]PrimeFactor, error) {
+	if n.signum <= 0 {
+		panic(errors.New("non-positive argument"))
+	}
+	return Factor(ctx, n)
+}
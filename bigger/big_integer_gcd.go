@@ -0,0 +1,190 @@
+package bigger
+
+import (
+	"math/bits"
+
+	"github.com/sineycoder/go-bigger/tool"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+var (
+	// p_LEHMER_GCD_THRESHOLD is the length in ints of the smaller operand from
+	// which Gcd and ExtendedGcd use Lehmer's algorithm instead of Euclid's.
+	p_LEHMER_GCD_THRESHOLD types.Int = 4
+)
+
+// ExtendedGcd returns an array of three bigIntegers g, x and y with
+// g = gcd(this, val) = this * x + val * y. The coefficients are the ones of
+// the extended Euclidean algorithm, |x| <= |val| / g and |y| <= |this| / g.
+func (b *bigInteger) ExtendedGcd(val *bigInteger) []*bigInteger {
+	if b.signum == 0 {
+		return []*bigInteger{val.Abs(), ZERO, BigIntegerValueOf(val.signum.ToLong())}
+	}
+	g, x := lehmerGcd(b, val, true)
+	if b.signum < 0 {
+		x = x.negate()
+	}
+	y := ZERO
+	if val.signum != 0 {
+		y = g.Subtract(b.Multiply(x)).Divide(val)
+	}
+	return []*bigInteger{g, x, y}
+}
+
+// Lcm returns the least common multiple of |this| and |val|, zero if either is zero.
+func (b *bigInteger) Lcm(val *bigInteger) *bigInteger {
+	if b.signum == 0 || val.signum == 0 {
+		return ZERO
+	}
+	return b.Divide(b.Gcd(val)).Multiply(val).Abs()
+}
+
+// GcdMany returns the greatest common divisor of the absolute values, zero for
+// no values or only zeros.
+func GcdMany(values ...*bigInteger) *bigInteger {
+	g := ZERO
+	for _, v := range values {
+		if g = g.Gcd(v); g.compareMagnituteLong(1) == 0 {
+			break
+		}
+	}
+	return g
+}
+
+// LcmMany returns the least common multiple of the absolute values, one for no
+// values and zero if any value is zero.
+func LcmMany(values ...*bigInteger) *bigInteger {
+	l := ONE
+	for _, v := range values {
+		if l = l.Lcm(v); l.signum == 0 {
+			break
+		}
+	}
+	return l
+}
+
+// lehmerGcd returns gcd(|a|, |b|) and, if extended is set, the coefficient x
+// with |a| x = gcd mod |b|. While both operands are long, Euclid's algorithm
+// is simulated on their leading 63 bits and the resulting quotients are
+// applied at once, in place, as a 2x2 matrix of single words.
+func lehmerGcd(a, b *bigInteger, extended bool) (*bigInteger, *bigInteger) {
+	A, B := newMutableBigIntegerByBigInteger(a), newMutableBigIntegerByBigInteger(b)
+	// ua and ub are the coefficients of |a| in A and B
+	var ua, ub *bigInteger
+	if extended {
+		ua, ub = ONE, ZERO
+	}
+	if A.compare(B) < 0 {
+		A, B, ua, ub = B, A, ub, ua
+	}
+	spareA, spareB := newMutableBigIntegerDefault(), newMutableBigIntegerDefault()
+	q := newMutableBigIntegerDefault()
+	for B.intLen > 2 {
+		u0, u1, v0, v1, even := lehmerSimulate(A, B)
+		if v0 == 0 {
+			// not a single quotient fits into the leading words, one full division
+			r := A.divideRemainder(B, q, true)
+			if extended {
+				ua, ub = ub, ua.Subtract(q.toBigInteger(1).Multiply(ub))
+			}
+			A, B = B, r
+			continue
+		}
+		// A = u0 A + v0 B and B = u1 A + v1 B, with u0, v1 >= 0 and u1, v0 <= 0
+		// for an even number of steps and the opposite signs for an odd one
+		if even {
+			spareA.setLinear(A, u0, B, v0)
+			spareB.setLinear(B, v1, A, u1)
+		} else {
+			spareA.setLinear(B, v0, A, u0)
+			spareB.setLinear(A, u1, B, v1)
+		}
+		A, B, spareA, spareB = spareA, spareB, A, B
+		if extended {
+			ua0, ua1 := ua.multiplyLong(types.Long(u0)), ua.multiplyLong(types.Long(u1))
+			ub0, ub1 := ub.multiplyLong(types.Long(v0)), ub.multiplyLong(types.Long(v1))
+			if even {
+				ua, ub = ua0.Subtract(ub0), ub1.Subtract(ua1)
+			} else {
+				ua, ub = ub0.Subtract(ua0), ua1.Subtract(ub1)
+			}
+		}
+	}
+
+	// the tail of at most 64 bits
+	x, y := A.toBigInteger(1), B.toBigInteger(1)
+	for y.signum != 0 {
+		qr := x.DivideAndRemainder(y)
+		x, y = y, qr[1]
+		if extended {
+			ua, ub = ub, ua.Subtract(qr[0].Multiply(ub))
+		}
+	}
+	return x, ua
+}
+
+// lehmerSimulate runs Euclid's algorithm on the leading 63 bits of A >= B with
+// Collins' stopping condition, which guarantees that the quotients agree with
+// the ones of the full operands. It returns the magnitudes of the cosequences,
+// their signs alternate with the number of steps as noted by even.
+func lehmerSimulate(A, B *mutableBigInteger) (u0, u1, v0, v1 uint64, even bool) {
+	shift := types.Int(A.BitLength() - 63)
+	a1, a2 := A.bitsFrom(shift), B.bitsFrom(shift)
+	var u2, v2 uint64
+	u0, u1, u2 = 0, 1, 0
+	v0, v1, v2 = 0, 0, 1
+	for a2 >= v2 && a1-a2 >= v1+v2 {
+		q, r := a1/a2, a1%a2
+		a1, a2 = a2, r
+		u0, u1, u2 = u1, u2, u1+q*u2
+		v0, v1, v2 = v1, v2, v1+q*v2
+		even = !even
+	}
+	return
+}
+
+// wordAt returns the i-th least significant int of m, zero beyond its length.
+func (m *mutableBigInteger) wordAt(i types.Int) uint64 {
+	if i >= m.intLen {
+		return 0
+	}
+	return uint64(uint32(m.value[m.offset+m.intLen-1-i]))
+}
+
+// bitsFrom returns the 64 bits of m starting at bit shift.
+func (m *mutableBigInteger) bitsFrom(shift types.Int) uint64 {
+	w, s := shift/32, uint(shift%32)
+	return m.wordAt(w)>>s | m.wordAt(w+1)<<(32-s) | m.wordAt(w+2)<<(64-s)
+}
+
+// setLinear sets m to x a - y b, which must not be negative. m must not share
+// its value with a or b, its array is reused when it is large enough.
+func (m *mutableBigInteger) setLinear(a *mutableBigInteger, x uint64, b *mutableBigInteger, y uint64) {
+	n := tool.MaxInt(a.intLen, b.intLen) + 3
+	if types.Int(cap(m.value)) < n {
+		m.value = make([]types.Int, n)
+	}
+	m.value, m.offset, m.intLen = m.value[:n], 0, n
+	var ca, cb uint64
+	var borrow int64
+	for i := types.Int(0); i < n; i++ {
+		hi, lo := bits.Mul64(x, a.wordAt(i))
+		lo, c := bits.Add64(lo, ca, 0)
+		hi += c
+		da := lo & 0xffffffff
+		ca = lo>>32 | hi<<32
+		hi, lo = bits.Mul64(y, b.wordAt(i))
+		lo, c = bits.Add64(lo, cb, 0)
+		hi += c
+		db := lo & 0xffffffff
+		cb = lo>>32 | hi<<32
+		d := int64(da) - int64(db) - borrow
+		borrow = 0
+		if d < 0 {
+			d += 1 << 32
+			borrow = 1
+		}
+		m.value[n-1-i] = types.Int(uint32(d))
+	}
+	m.normalize()
+}
@@ -0,0 +1,93 @@
+package bigger
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Log2Floor returns floor(log2(this)) for a positive bigInteger.
+func (b *bigInteger) Log2Floor() types.Int {
+	if b.signum <= 0 {
+		panic(errors.New("logarithm of a non-positive bigInteger"))
+	}
+	return b.BitLength() - 1
+}
+
+// Log10Floor returns floor(log10(this)) for a positive bigInteger.
+func (b *bigInteger) Log10Floor() types.Int {
+	if b.signum <= 0 {
+		panic(errors.New("logarithm of a non-positive bigInteger"))
+	}
+	return bigDigitLength(b) - 1
+}
+
+// ILog returns the largest e with base^e <= this, for a positive bigInteger
+// and base >= 2.
+func (b *bigInteger) ILog(base *bigInteger) types.Int {
+	if b.signum <= 0 {
+		panic(errors.New("logarithm of a non-positive bigInteger"))
+	}
+	if base.signum <= 0 || base.compareMagnituteLong(2) < 0 {
+		panic(errors.New("logarithm base must be at least 2"))
+	}
+	if base.getLowestSetBit() == base.BitLength()-1 {
+		return (b.BitLength() - 1) / (base.BitLength() - 1)
+	}
+	return b.ilog(base)
+}
+
+// DigitCount returns the number of digits of the magnitude of this bigInteger
+// in the given radix, that is the length of StringRadix(radix) without the
+// sign, without building the string. Zero has one digit.
+func (b *bigInteger) DigitCount(radix types.Int) types.Int {
+	if b.signum == 0 {
+		return 1
+	}
+	if radix < 2 || radix > 36 {
+		radix = 10
+	}
+	if radix&(radix-1) == 0 {
+		shift := types.Int(bits.TrailingZeros32(uint32(radix)))
+		return (b.Abs().BitLength() + shift - 1) / shift
+	}
+	if radix == 10 {
+		return bigDigitLength(b)
+	}
+	return b.Abs().ilog(BigIntegerValueOf(radix.ToLong())) + 1
+}
+
+// ilog returns floor(log(|b|) / log(base)). The estimate from the leading
+// bits of both numbers is off by at most a few units, it is corrected by
+// comparing with the exact power.
+func (b *bigInteger) ilog(base *bigInteger) types.Int {
+	x := b.Abs()
+	if x.CompareTo(base) < 0 {
+		return 0
+	}
+	e := types.Int(log2Approx(x)/log2Approx(base)) - 1
+	if e < 0 {
+		e = 0
+	}
+	p := base.Pow(e)
+	for p.CompareTo(x) > 0 {
+		p = p.Divide(base)
+		e--
+	}
+	for next := p.Multiply(base); next.CompareTo(x) <= 0; next = next.Multiply(base) {
+		p = next
+		e++
+	}
+	return e
+}
+
+// log2Approx returns log2 of a positive bigInteger from its leading 63 bits.
+func log2Approx(x *bigInteger) float64 {
+	shift := x.BitLength() - 63
+	if shift < 0 {
+		shift = 0
+	}
+	return math.Log2(float64(x.shiftRight(shift).LongValue())) + float64(shift)
+}
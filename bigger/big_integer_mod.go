@@ -0,0 +1,262 @@
+package bigger
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/sineycoder/go-bigger/tool"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+var (
+	// p_SMALL_PRIMES are the primes below 256, used for trial division before the
+	// probabilistic tests
+	p_SMALL_PRIMES = primesUpTo(255)
+)
+
+// Signum returns -1, 0 or 1 as this bigInteger is negative, zero or positive.
+func (b *bigInteger) Signum() types.Int {
+	return b.signum
+}
+
+// Negate returns -this.
+func (b *bigInteger) Negate() *bigInteger {
+	return b.negate()
+}
+
+// Remainder returns this % val, which has the sign of this bigInteger.
+func (b *bigInteger) Remainder(val *bigInteger) *bigInteger {
+	if types.Int(len(val.mag)) < p_BURNIKEL_ZIEGLER_THRESHOLD || types.Int(len(b.mag)-len(val.mag)) < p_BURNIKEL_ZIEGLER_OFFSET {
+		q := newMutableBigIntegerDefault()
+		a := newMutableBigIntegerArray(b.mag)
+		bb := newMutableBigIntegerArray(val.mag)
+		return a.divideKnuth(bb, q, true).toBigInteger(b.signum)
+	}
+	return b.divideAndRemainderBurnikelZiegler(val, nil)[1]
+}
+
+// Mod returns this mod m, which is never negative. The modulus must be positive.
+func (b *bigInteger) Mod(m *bigInteger) *bigInteger {
+	if m.signum <= 0 {
+		panic(errors.New("BigInteger: modulus not positive"))
+	}
+	r := b.Remainder(m)
+	if r.signum < 0 {
+		return r.Add(m)
+	}
+	return r
+}
+
+// Gcd returns the greatest common divisor of |this| and |val|, zero when both
+// are zero.
+func (b *bigInteger) Gcd(val *bigInteger) *bigInteger {
+	if tool.MinInt(types.Int(len(b.mag)), types.Int(len(val.mag))) >= p_LEHMER_GCD_THRESHOLD {
+		g, _ := lehmerGcd(b, val, false)
+		return g
+	}
+	x, y := b.Abs(), val.Abs()
+	for y.signum != 0 {
+		x, y = y, x.Remainder(y)
+	}
+	return x
+}
+
+// ModInverse returns this^-1 mod m. It panics when m is not positive or this
+// bigInteger has no inverse modulo m.
+func (b *bigInteger) ModInverse(m *bigInteger) *bigInteger {
+	if m.signum <= 0 {
+		panic(errors.New("BigInteger: modulus not positive"))
+	}
+	if m.compareMagnituteLong(1) == 0 {
+		return ZERO
+	}
+	a := b.Mod(m)
+	if a.signum == 0 {
+		panic(errors.New("BigInteger not invertible."))
+	}
+	g, x := lehmerGcd(a, m, true)
+	if g.compareMagnituteLong(1) != 0 {
+		panic(errors.New("BigInteger not invertible."))
+	}
+	return x.Mod(m)
+}
+
+// ModPow returns this^exponent mod m. A negative exponent is allowed when this
+// bigInteger is invertible modulo m. The context of the last modulus is kept,
+// so powers to one modulus in a row share its precomputations; interleaved
+// moduli are better served by a modContext each.
+func (b *bigInteger) ModPow(exponent, m *bigInteger) *bigInteger {
+	return cachedModContext(m).ExpMod(b, exponent)
+}
+
+// IsProbablePrime returns true if this bigInteger is probably prime and false
+// if it is definitely composite. The probability that a composite passes is
+// below 2^-certainty, certainty <= 0 always returns true. The test is a
+// Baillie-PSW test, a Miller-Rabin round to base 2 and a Lucas test, extended
+// by further Miller-Rabin rounds for high certainties.
+func (b *bigInteger) IsProbablePrime(certainty types.Int) bool {
+	if certainty <= 0 {
+		return true
+	}
+	w := b.Abs()
+	if w.compareMagnituteLong(256) < 0 {
+		if w.signum == 0 {
+			return false
+		}
+		v := types.Int(w.LongValue())
+		i := sort.Search(len(p_SMALL_PRIMES), func(i int) bool { return p_SMALL_PRIMES[i] >= v })
+		return i < len(p_SMALL_PRIMES) && p_SMALL_PRIMES[i] == v
+	}
+	if !w.testBit(0) {
+		return false
+	}
+	for _, p := range p_SMALL_PRIMES[1:] {
+		if w.remainderInt(p) == 0 {
+			return false
+		}
+	}
+	if !w.passesMillerRabin(2) || !w.passesLucas() {
+		return false
+	}
+	// Baillie-PSW alone has no known counterexample, the extra rounds back the
+	// promised bound, with the JDK's round counts by size
+	rounds := (certainty + 1) / 2
+	sizeInBits := w.BitLength()
+	if sizeInBits < 100 {
+		rounds = tool.MinInt(rounds, 50)
+	} else if sizeInBits < 256 {
+		rounds = tool.MinInt(rounds, 27)
+	} else if sizeInBits < 512 {
+		rounds = tool.MinInt(rounds, 15)
+	} else if sizeInBits < 768 {
+		rounds = tool.MinInt(rounds, 8)
+	} else if sizeInBits < 1024 {
+		rounds = tool.MinInt(rounds, 4)
+	} else {
+		rounds = tool.MinInt(rounds, 2)
+	}
+	for _, a := range p_SMALL_PRIMES[1:rounds] {
+		if !w.passesMillerRabin(a.ToLong()) {
+			return false
+		}
+	}
+	return true
+}
+
+// passesMillerRabin returns true if this odd bigInteger > base is a strong
+// probable prime to the base.
+func (b *bigInteger) passesMillerRabin(base types.Long) bool {
+	nMinusOne := b.add(-1)
+	a := nMinusOne.getLowestSetBit()
+	m := nMinusOne.shiftRight(a)
+	z := BigIntegerValueOf(base).ModPow(m, b)
+	if z.compareMagnituteLong(1) == 0 || z.CompareTo(nMinusOne) == 0 {
+		return true
+	}
+	for j := types.Int(1); j < a; j++ {
+		z = z.square().Mod(b)
+		if z.CompareTo(nMinusOne) == 0 {
+			return true
+		}
+		if z.compareMagnituteLong(1) == 0 {
+			return false
+		}
+	}
+	return false
+}
+
+// passesLucas returns true if this odd bigInteger is a Lucas probable prime
+// with Selfridge's parameters: the first d of 5, -7, 9, -11, ... with
+// jacobi(d, this) = -1, P = 1 and Q = (1 - d) / 4.
+func (b *bigInteger) passesLucas() bool {
+	if b.IsPerfectSquare() {
+		// no such d exists for squares
+		return false
+	}
+	d := types.Int(5)
+	for jacobiSymbol(d, b) != -1 {
+		if d < 0 {
+			d = -d + 2
+		} else {
+			d = -(d + 2)
+		}
+	}
+	u := lucasSequence(d, b.add(1), b)
+	return u.Mod(b).signum == 0
+}
+
+// lucasSequence returns U(k) mod n of the Lucas sequence with P = 1 and
+// Q = (1 - z) / 4, tracking U and V by the doubling formulas
+// U(2k) = U(k) V(k) and V(2k) = (V(k)^2 + z U(k)^2) / 2.
+func lucasSequence(z types.Int, k, n *bigInteger) *bigInteger {
+	d := BigIntegerValueOf(z.ToLong())
+	u, v := ONE, ONE
+	half := func(x *bigInteger) *bigInteger {
+		if x.testBit(0) {
+			x = x.Subtract(n)
+		}
+		return x.shiftRight(1)
+	}
+	for i := k.BitLength() - 2; i >= 0; i-- {
+		u, v = u.Multiply(v).Mod(n), half(v.square().Add(d.Multiply(u.square())).Mod(n))
+		if k.testBit(i) {
+			u, v = half(u.Add(v).Mod(n)), half(v.Add(d.Multiply(u)).Mod(n))
+		}
+	}
+	return u
+}
+
+// jacobiSymbol returns the Jacobi symbol (p/n) for an odd positive n.
+func jacobiSymbol(p types.Int, n *bigInteger) types.Int {
+	if p == 0 {
+		return 0
+	}
+	j := types.Int(1)
+	u := n.mag[len(n.mag)-1]
+
+	// make p positive
+	if p < 0 {
+		p = -p
+		if n8 := u & 7; n8 == 3 || n8 == 7 {
+			j = -j
+		}
+	}
+	// get rid of factors of 2 in p
+	for p&3 == 0 {
+		p >>= 2
+	}
+	if p&1 == 0 {
+		p >>= 1
+		if (u^(u>>1))&2 != 0 {
+			j = -j
+		}
+	}
+	if p == 1 {
+		return j
+	}
+	// apply quadratic reciprocity
+	if p&u&2 != 0 {
+		j = -j
+	}
+	u = n.remainderInt(p)
+	for u != 0 {
+		for u&3 == 0 {
+			u >>= 2
+		}
+		if u&1 == 0 {
+			u >>= 1
+			if (p^(p>>1))&2 != 0 {
+				j = -j
+			}
+		}
+		if u == 1 {
+			return j
+		}
+		u, p = p, u
+		if u&p&2 != 0 {
+			j = -j
+		}
+		u %= p
+	}
+	return 0
+}
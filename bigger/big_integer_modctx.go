@@ -0,0 +1,202 @@
+package bigger
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+var (
+	// p_MONTGOMERY_THRESHOLD is the modulus length in ints up to which odd
+	// moduli are multiplied in Montgomery form, above it Barrett reduction with
+	// the faster multiplications wins.
+	p_MONTGOMERY_THRESHOLD types.Int = 256
+)
+
+// modContext holds the precomputations for arithmetic modulo a fixed modulus.
+// Reduce uses Barrett's reciprocal; odd moduli additionally get Montgomery
+// constants for MulMod, SqrMod and ExpMod. A modContext is immutable and may
+// be shared by goroutines.
+type modContext struct {
+	modulus *bigInteger
+	k       types.Int   // the length of the modulus in ints
+	mu      *bigInteger // floor(2^(64k) / modulus)
+	mont    *montgomery // nil for even or long moduli
+}
+
+// NewModContext prepares arithmetic modulo the positive m.
+func NewModContext(m *bigInteger) *modContext {
+	if m.signum <= 0 {
+		panic(errors.New("BigInteger: modulus not positive"))
+	}
+	k := types.Int(len(m.mag))
+	c := &modContext{modulus: m, k: k, mu: ONE.shiftLeft(64 * k).Divide(m)}
+	if m.testBit(0) && m.compareMagnituteLong(1) != 0 && k <= p_MONTGOMERY_THRESHOLD {
+		c.mont = newMontgomery(m)
+	}
+	return c
+}
+
+var (
+	// lastModContext is the context of the latest ModPow modulus, shared by a
+	// run of powers to one modulus like the rounds of IsProbablePrime
+	lastModContext     *modContext
+	lastModContextLock sync.Mutex
+)
+
+// cachedModContext returns the context for m, the last one built when its
+// modulus equals m.
+func cachedModContext(m *bigInteger) *modContext {
+	lastModContextLock.Lock()
+	c := lastModContext
+	lastModContextLock.Unlock()
+	if c != nil && c.modulus.CompareTo(m) == 0 {
+		return c
+	}
+	c = NewModContext(m)
+	lastModContextLock.Lock()
+	lastModContext = c
+	lastModContextLock.Unlock()
+	return c
+}
+
+// Modulus returns the modulus.
+func (c *modContext) Modulus() *bigInteger {
+	return c.modulus
+}
+
+// Reduce returns x mod m in [0, m).
+func (c *modContext) Reduce(x *bigInteger) *bigInteger {
+	if x.signum < 0 {
+		r := c.Reduce(x.negate())
+		if r.signum != 0 {
+			r = c.modulus.Subtract(r)
+		}
+		return r
+	}
+	if x.compareMagnitute(c.modulus) < 0 {
+		return x
+	}
+	if types.Int(len(x.mag)) > 2*c.k {
+		return x.Mod(c.modulus)
+	}
+	// the estimate q is at most two below the true quotient
+	q := x.shiftRight(32 * (c.k - 1)).Multiply(c.mu).shiftRight(32 * (c.k + 1))
+	r := x.Subtract(q.Multiply(c.modulus))
+	for r.compareMagnitute(c.modulus) >= 0 {
+		r = r.Subtract(c.modulus)
+	}
+	return r
+}
+
+// MulMod returns a b mod m.
+func (c *modContext) MulMod(a, b *bigInteger) *bigInteger {
+	a, b = c.Reduce(a), c.Reduce(b)
+	if c.mont == nil {
+		return c.Reduce(a.Multiply(b))
+	}
+	// (a b R^-1) R^2 R^-1 = a b
+	t := c.mont.scratch()
+	z := make([]types.Int, c.k)
+	c.mont.multiply(NewFixedMagnitude(a, c.k).value, NewFixedMagnitude(b, c.k).value, z, t)
+	c.mont.multiply(z, c.mont.rr, z, t)
+	return (&fixedMagnitude{value: z}).BigInteger()
+}
+
+// SqrMod returns a^2 mod m.
+func (c *modContext) SqrMod(a *bigInteger) *bigInteger {
+	if c.mont == nil {
+		return c.Reduce(c.Reduce(a).square())
+	}
+	return c.MulMod(a, a)
+}
+
+// ExpMod returns base^exponent mod m like ModPow. A negative exponent is
+// allowed when base is invertible modulo m.
+func (c *modContext) ExpMod(base, exponent *bigInteger) *bigInteger {
+	if c.modulus.compareMagnituteLong(1) == 0 {
+		return ZERO
+	}
+	if exponent.signum == 0 {
+		return ONE
+	}
+	base = c.Reduce(base)
+	if exponent.signum < 0 {
+		base, exponent = c.Inverse(base), exponent.negate()
+	}
+	if c.mont != nil {
+		return c.expMontgomery(base, exponent)
+	}
+
+	// left to right with a fixed window of 4 bits
+	var table [16]*bigInteger
+	table[0], table[1] = ONE, base
+	for i := 2; i < 16; i++ {
+		table[i] = c.Reduce(table[i-1].Multiply(base))
+	}
+	result := ONE
+	for i := (exponent.BitLength() + 3) / 4 * 4; i > 0; i -= 4 {
+		if result != ONE {
+			for k := 0; k < 4; k++ {
+				result = c.Reduce(result.square())
+			}
+		}
+		if w := exponentWindow(exponent, i); w != 0 {
+			result = c.Reduce(result.Multiply(table[w]))
+		}
+	}
+	return result
+}
+
+// expMontgomery is ExpMod for 0 <= base < m in Montgomery form.
+func (c *modContext) expMontgomery(base, exponent *bigInteger) *bigInteger {
+	mt, t := c.mont, c.mont.scratch()
+	var table [16][]types.Int
+	table[1] = mt.toMontgomery(NewFixedMagnitude(base, c.k).value, t)
+	for i := 2; i < 16; i++ {
+		table[i] = make([]types.Int, c.k)
+		mt.multiply(table[i-1], table[1], table[i], t)
+	}
+	var result []types.Int
+	for i := (exponent.BitLength() + 3) / 4 * 4; i > 0; i -= 4 {
+		if result != nil {
+			for k := 0; k < 4; k++ {
+				mt.multiply(result, result, result, t)
+			}
+		}
+		if w := exponentWindow(exponent, i); w != 0 {
+			if result == nil {
+				result = append([]types.Int(nil), table[w]...)
+			} else {
+				mt.multiply(result, table[w], result, t)
+			}
+		}
+	}
+	return (&fixedMagnitude{value: mt.fromMontgomery(result, t)}).BigInteger()
+}
+
+// Inverse returns a^-1 mod m. It panics when a is not invertible.
+func (c *modContext) Inverse(a *bigInteger) *bigInteger {
+	a = c.Reduce(a)
+	if a.signum == 0 {
+		panic(errors.New("BigInteger not invertible."))
+	}
+	g, x := lehmerGcd(a, c.modulus, true)
+	if g.compareMagnituteLong(1) != 0 {
+		panic(errors.New("BigInteger not invertible."))
+	}
+	return c.Reduce(x)
+}
+
+// exponentWindow returns the bits i-1 down to i-4 of the exponent.
+func exponentWindow(exponent *bigInteger, i types.Int) int {
+	w := 0
+	for k := types.Int(1); k <= 4; k++ {
+		w <<= 1
+		if exponent.testBit(i - k) {
+			w |= 1
+		}
+	}
+	return w
+}
@@ -0,0 +1,247 @@
+package bigger
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Multiplication and squaring by number-theoretic transform (NTT).
+//
+// The 32-bit words of both magnitudes are used directly as coefficients and
+// the cyclic convolution is computed modulo two NTT-friendly primes below
+// 2^62. Every coefficient of the product is smaller than
+// min(xlen, ylen) * 2^64, which is far below p1 * p2 (about 2^122), so the
+// exact coefficients are recovered by CRT and the carries are propagated at
+// the end. All modular arithmetic is done in Montgomery form with R = 2^64.
+
+var (
+	// p_FFT_MULTIPLY_THRESHOLD is the length in ints of both operands above
+	// which the NTT multiplication is used instead of Toom-Cook 3.
+	p_FFT_MULTIPLY_THRESHOLD types.Int = 1792
+	// p_FFT_SQUARE_THRESHOLD is the length in ints above which the NTT
+	// squaring is used instead of Toom-Cook 3.
+	p_FFT_SQUARE_THRESHOLD types.Int = 1792
+
+	// nttPrimes must be sorted ascending, the CRT step relies on p1 < p2.
+	nttPrimes = [2]*nttPrime{
+		newNttPrime(27<<56+1, 5, 56), // 1945555039024054273
+		newNttPrime(29<<57+1, 3, 57), // 4179340454199820289
+	}
+	// nttCrtFactor is p1^-1 mod p2 in Montgomery form of p2.
+	nttCrtFactor = nttPrimes[1].pow(nttPrimes[1].toMont(nttPrimes[0].p), nttPrimes[1].p-2)
+)
+
+type nttPrime struct {
+	p      uint64 // the prime, c * 2^maxLog + 1
+	pinv   uint64 // -p^-1 mod 2^64
+	r2     uint64 // 2^128 mod p
+	g      uint64 // a primitive root modulo p
+	maxLog uint   // the largest supported transform is 2^maxLog
+}
+
+func newNttPrime(p, g uint64, maxLog uint) *nttPrime {
+	inv := p // Newton iteration, every step doubles the correct low bits
+	for i := 0; i < 5; i++ {
+		inv *= 2 - p*inv
+	}
+	r := bits.Rem64(1, 0, p)
+	hi, lo := bits.Mul64(r, r)
+	return &nttPrime{p: p, pinv: -inv, r2: bits.Rem64(hi, lo, p), g: g, maxLog: maxLog}
+}
+
+// redc returns (hi*2^64 + lo) / 2^64 mod p, the input must be below p*2^64.
+func (np *nttPrime) redc(hi, lo uint64) uint64 {
+	m := lo * np.pinv
+	mh, ml := bits.Mul64(m, np.p)
+	_, c := bits.Add64(lo, ml, 0)
+	t := hi + mh + c
+	if t >= np.p {
+		t -= np.p
+	}
+	return t
+}
+
+func (np *nttPrime) mul(a, b uint64) uint64 {
+	return np.redc(bits.Mul64(a, b))
+}
+
+func (np *nttPrime) add(a, b uint64) uint64 {
+	s := a + b
+	if s >= np.p {
+		s -= np.p
+	}
+	return s
+}
+
+func (np *nttPrime) sub(a, b uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return a + np.p - b
+}
+
+func (np *nttPrime) toMont(a uint64) uint64 {
+	return np.mul(a, np.r2)
+}
+
+func (np *nttPrime) fromMont(a uint64) uint64 {
+	return np.redc(0, a)
+}
+
+// pow returns a^e for a in Montgomery form, the result is in Montgomery form too.
+func (np *nttPrime) pow(a, e uint64) uint64 {
+	r := np.toMont(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r = np.mul(r, a)
+		}
+		a = np.mul(a, a)
+	}
+	return r
+}
+
+// roots returns w^0 .. w^(n/2-1) in Montgomery form for a primitive n-th root
+// of unity w, or of its inverse when inverse is set.
+func (np *nttPrime) roots(n int, inverse bool) []uint64 {
+	w := np.pow(np.toMont(np.g), (np.p-1)/uint64(n))
+	if inverse {
+		w = np.pow(w, uint64(n-1))
+	}
+	r := make([]uint64, n/2)
+	if len(r) > 0 {
+		r[0] = np.toMont(1)
+	}
+	for i := 1; i < len(r); i++ {
+		r[i] = np.mul(r[i-1], w)
+	}
+	return r
+}
+
+// forward is a decimation in frequency transform, natural order in and
+// bit-reversed order out.
+func (np *nttPrime) forward(a, roots []uint64) {
+	n := len(a)
+	for half := n / 2; half >= 1; half >>= 1 {
+		step := n / 2 / half
+		for i := 0; i < n; i += 2 * half {
+			for j := 0; j < half; j++ {
+				u, v := a[i+j], a[i+j+half]
+				a[i+j] = np.add(u, v)
+				a[i+j+half] = np.mul(np.sub(u, v), roots[j*step])
+			}
+		}
+	}
+}
+
+// inverse is a decimation in time transform, bit-reversed order in and
+// natural order out. The result is not scaled by 1/n.
+func (np *nttPrime) inverse(a, roots []uint64) {
+	n := len(a)
+	for half := 1; half < n; half <<= 1 {
+		step := n / 2 / half
+		for i := 0; i < n; i += 2 * half {
+			for j := 0; j < half; j++ {
+				u, v := a[i+j], np.mul(a[i+j+half], roots[j*step])
+				a[i+j] = np.add(u, v)
+				a[i+j+half] = np.sub(u, v)
+			}
+		}
+	}
+}
+
+// load puts the magnitude into a little-endian coefficient array of length n
+// in Montgomery form.
+func (np *nttPrime) load(mag []types.Int, n int) []uint64 {
+	a := make([]uint64, n)
+	for i, j := 0, len(mag)-1; j >= 0; i, j = i+1, j-1 {
+		a[i] = np.toMont(uint64(uint32(mag[j])))
+	}
+	return a
+}
+
+// convolve returns the cyclic convolution of x and y (y == nil for squaring)
+// modulo the prime, in normal form.
+func (np *nttPrime) convolve(x, y []types.Int, n int) []uint64 {
+	roots := np.roots(n, false)
+	a := np.load(x, n)
+	np.forward(a, roots)
+	if y == nil {
+		for i := range a {
+			a[i] = np.mul(a[i], a[i])
+		}
+	} else {
+		b := np.load(y, n)
+		np.forward(b, roots)
+		for i := range a {
+			a[i] = np.mul(a[i], b[i])
+		}
+	}
+	np.inverse(a, np.roots(n, true))
+	// a Montgomery product with the plain n^-1 scales and leaves Montgomery form at once
+	scale := np.fromMont(np.pow(np.toMont(uint64(n)), np.p-2))
+	for i := range a {
+		a[i] = np.mul(a[i], scale)
+	}
+	return a
+}
+
+// nttMultiply returns the magnitude of x * y, y == nil means x * x.
+func nttMultiply(x, y []types.Int) []types.Int {
+	ylen := len(x)
+	if y != nil {
+		ylen = len(y)
+	}
+	zlen := len(x) + ylen
+	n := 1
+	for n < zlen-1 {
+		n <<= 1
+	}
+	if uint(bits.TrailingZeros(uint(n))) > nttPrimes[0].maxLog {
+		panic(errors.New("overflow"))
+	}
+
+	var c1, c2 []uint64
+	join := fork(types.Int(len(x)), func() { c1 = nttPrimes[0].convolve(x, y, n) })
+	c2 = nttPrimes[1].convolve(x, y, n)
+	join()
+	return nttCombine(c1, c2, zlen)
+}
+
+// nttCombine recovers the exact coefficients from their residues and
+// propagates the carries into a big-endian magnitude of length zlen.
+func nttCombine(c1, c2 []uint64, zlen int) []types.Int {
+	p1, q := nttPrimes[0].p, nttPrimes[1]
+	z := make([]types.Int, zlen)
+	var chi, clo uint64
+	for i := 0; i < zlen; i++ {
+		if i < zlen-1 {
+			// c = r1 + p1 * ((r2 - r1) * p1^-1 mod p2), r1 < p1 < p2
+			r1 := c1[i]
+			t := q.mul(q.sub(c2[i], r1), nttCrtFactor)
+			hi, lo := bits.Mul64(p1, t)
+			var carry uint64
+			lo, carry = bits.Add64(lo, r1, 0)
+			hi += carry
+			clo, carry = bits.Add64(clo, lo, 0)
+			chi += hi + carry
+		}
+		z[zlen-1-i] = types.Int(uint32(clo))
+		clo = clo>>32 | chi<<32
+		chi >>= 32
+	}
+	return z
+}
+
+func multiplyNTT(x, y *bigInteger) *bigInteger {
+	result := trustedStripLeadingZeroInts(nttMultiply(x.mag, y.mag))
+	if x.signum == y.signum {
+		return newBigInteger(result, 1)
+	}
+	return newBigInteger(result, -1)
+}
+
+func (b *bigInteger) squareNTT() *bigInteger {
+	return newBigInteger(trustedStripLeadingZeroInts(nttMultiply(b.mag, nil)), 1)
+}
@@ -0,0 +1,67 @@
+package bigger
+
+import (
+	"errors"
+	"io"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// RandomBits returns a uniformly distributed bigInteger in [0, 2^n). It reads
+// (n+7)/8 bytes from r, big-endian, so a seeded reader gives the same value
+// on every run.
+func RandomBits(n types.Int, r io.Reader) (*bigInteger, error) {
+	if n < 0 {
+		panic(errors.New("numBits must be non-negative"))
+	}
+	if n == 0 {
+		return ZERO, nil
+	}
+	buf := make([]byte, (n+7)/8)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	// drop the excess bits of the leading byte
+	buf[0] &= byte(0xff >> (types.Int(len(buf))*8 - n))
+	mag := stripLeadingZeroInts(buf)
+	if len(mag) == 0 {
+		return ZERO, nil
+	}
+	return newBigInteger(mag, 1), nil
+}
+
+// RandomInRange returns a uniformly distributed bigInteger in [lo, hi). Values
+// of hi - lo - 1 bits are drawn until one is below hi - lo, which avoids the
+// bias of reducing a larger random value modulo the range.
+func RandomInRange(lo, hi *bigInteger, r io.Reader) (*bigInteger, error) {
+	d := hi.Subtract(lo)
+	if d.signum <= 0 {
+		panic(errors.New("empty range"))
+	}
+	n := d.add(-1).BitLength()
+	for {
+		x, err := RandomBits(n, r)
+		if err != nil {
+			return nil, err
+		}
+		if x.CompareTo(d) < 0 {
+			return lo.Add(x), nil
+		}
+	}
+}
+
+// RandomBigDecimal returns a uniformly distributed bigDecimal of the given
+// scale in [lo, hi), i.e. one of the multiples of 10^-scale in that range.
+func RandomBigDecimal(lo, hi *bigDecimal, scale types.Int, r io.Reader) (*bigDecimal, error) {
+	// x < hi exactly when the unscaled x is below hi 10^scale rounded up
+	l := lo.SetScale(scale, ROUND_CEILING).inflated()
+	h := hi.SetScale(scale, ROUND_CEILING).inflated()
+	if h.CompareTo(l) <= 0 {
+		panic(errors.New("empty range"))
+	}
+	x, err := RandomInRange(l, h, r)
+	if err != nil {
+		return nil, err
+	}
+	return newBigDecimalByBigInteger2(x, scale), nil
+}
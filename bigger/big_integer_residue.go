@@ -0,0 +1,239 @@
+package bigger
+
+import (
+	"errors"
+
+	"github.com/sineycoder/go-bigger/tool"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Jacobi returns the Jacobi symbol (a/n) for an odd positive n.
+func Jacobi(a, n *bigInteger) types.Int {
+	if n.signum <= 0 || !n.testBit(0) {
+		panic(errors.New("Jacobi symbol needs an odd positive modulus"))
+	}
+	a = a.Mod(n)
+	t := types.Int(1)
+	for a.signum != 0 {
+		// (2/n) = -1 exactly for n = 3, 5 mod 8
+		z := a.getLowestSetBit()
+		a = a.shiftRight(z)
+		if n8 := n.mag[len(n.mag)-1] & 7; z&1 == 1 && (n8 == 3 || n8 == 5) {
+			t = -t
+		}
+		// quadratic reciprocity
+		a, n = n, a
+		if a.mag[len(a.mag)-1]&3 == 3 && n.mag[len(n.mag)-1]&3 == 3 {
+			t = -t
+		}
+		a = a.Mod(n)
+	}
+	if n.compareMagnituteLong(1) == 0 {
+		return t
+	}
+	return 0
+}
+
+// Kronecker returns the Kronecker symbol (a/n), the extension of the Jacobi
+// symbol to all n.
+func Kronecker(a, n *bigInteger) types.Int {
+	if n.signum == 0 {
+		if a.compareMagnituteLong(1) == 0 {
+			return 1
+		}
+		return 0
+	}
+	t := types.Int(1)
+	if n.signum < 0 {
+		n = n.negate()
+		if a.signum < 0 {
+			t = -t
+		}
+	}
+	if z := n.getLowestSetBit(); z > 0 {
+		// (a/2) is 0 for even a and -1 exactly for a = 3, 5 mod 8
+		if a.signum == 0 || !a.testBit(0) {
+			return 0
+		}
+		if a8 := a.Mod(BigIntegerValueOf(8)).LongValue(); z&1 == 1 && (a8 == 3 || a8 == 5) {
+			t = -t
+		}
+		n = n.shiftRight(z)
+	}
+	if n.compareMagnituteLong(1) == 0 {
+		return t
+	}
+	return t * Jacobi(a, n)
+}
+
+// ModSqrt returns the smaller square root of a modulo the prime p and true, or
+// nil and false when a is a quadratic non-residue. The result is unspecified
+// when p is not prime.
+func ModSqrt(a, p *bigInteger) (*bigInteger, bool) {
+	if p.signum <= 0 {
+		panic(errors.New("BigInteger: modulus not positive"))
+	}
+	a = a.Mod(p)
+	if a.signum == 0 || p.compareMagnituteLong(2) == 0 {
+		return a, true
+	}
+	if Jacobi(a, p) != 1 {
+		return nil, false
+	}
+	var r *bigInteger
+	switch p8 := p.mag[len(p.mag)-1] & 7; {
+	case p8&3 == 3:
+		// r = a^((p+1)/4)
+		r = a.ModPow(p.add(1).shiftRight(2), p)
+	case p8 == 5:
+		// Atkin: v = (2a)^((p-5)/8), i = 2av^2, r = av(i-1)
+		v := a.shiftLeft(1).ModPow(p.shiftRight(3), p)
+		i := a.shiftLeft(1).Multiply(v.square()).Mod(p)
+		r = a.Multiply(v).Mod(p).Multiply(i.add(-1)).Mod(p)
+	default:
+		// Tonelli-Shanks takes O(s^2) multiplications for p - 1 = q 2^s,
+		// Cipolla a fixed number per bit
+		if s := p.add(-1).getLowestSetBit(); s*(s-1) > 8*p.BitLength()+20 {
+			r = cipolla(a, p)
+		} else {
+			r = tonelliShanks(a, p, s)
+		}
+	}
+	return smallerRoot(r, p), true
+}
+
+// ModSqrtPrimePower returns the smaller square root of a modulo p^e for a
+// prime p and e >= 1 and true, or nil and false when there is none. The root
+// modulo p is lifted by Hensel's lemma.
+func ModSqrtPrimePower(a, p *bigInteger, e types.Int) (*bigInteger, bool) {
+	if e < 1 {
+		panic(errors.New("non-positive prime power exponent"))
+	}
+	pe := p.Pow(e)
+	a = a.Mod(pe)
+	if a.signum == 0 {
+		return ZERO, true
+	}
+	// a = p^v b with p not dividing b needs an even v, the root is p^(v/2) sqrt(b)
+	v, b := types.Int(0), a
+	for ; b.Mod(p).signum == 0; v++ {
+		b = b.Divide(p)
+	}
+	if v&1 == 1 {
+		return nil, false
+	}
+	k := e - v // the root of b is needed modulo p^k
+	var r *bigInteger
+	if p.compareMagnituteLong(2) == 0 {
+		var ok bool
+		if r, ok = sqrtModPowerOfTwo(b, k); !ok {
+			return nil, false
+		}
+	} else {
+		var ok bool
+		if r, ok = ModSqrt(b, p); !ok {
+			return nil, false
+		}
+		// r' = r - (r^2 - b) / (2r), every step doubles the precision
+		for j := types.Int(1); j < k; {
+			j = tool.MinInt(2*j, k)
+			m := p.Pow(j)
+			r = r.Subtract(r.square().Subtract(b).Multiply(r.shiftLeft(1).ModInverse(m))).Mod(m)
+		}
+	}
+	return smallerRoot(r.Multiply(p.Pow(v/2)).Mod(pe), pe), true
+}
+
+// sqrtModPowerOfTwo returns a square root of the odd b modulo 2^k.
+func sqrtModPowerOfTwo(b *bigInteger, k types.Int) (*bigInteger, bool) {
+	if k <= 1 {
+		return ONE, true
+	}
+	b8 := b.Mod(BigIntegerValueOf(8)).LongValue()
+	if k == 2 {
+		return ONE, b8&3 == 1
+	}
+	if b8 != 1 {
+		return nil, false
+	}
+	// r is a root modulo 2^j, r or r + 2^(j-1) is one modulo 2^(j+1)
+	r := ONE
+	for j := types.Int(3); j < k; j++ {
+		if r.square().Subtract(b).shiftRight(j).testBit(0) {
+			r = r.Add(ONE.shiftLeft(j - 1))
+		}
+	}
+	return r, true
+}
+
+// tonelliShanks returns a square root of the residue a modulo the prime
+// p = q 2^s + 1.
+func tonelliShanks(a, p *bigInteger, s types.Int) *bigInteger {
+	q := p.add(-1).shiftRight(s)
+	z := nonResidue(p, func(z *bigInteger) *bigInteger { return z })
+	m, c, t := s, z.ModPow(q, p), a.ModPow(q, p)
+	r := a.ModPow(q.add(1).shiftRight(1), p)
+	for t.compareMagnituteLong(1) != 0 {
+		// the least i with t^(2^i) = 1
+		i, t2 := types.Int(0), t
+		for ; t2.compareMagnituteLong(1) != 0; i++ {
+			if i == m {
+				panic(errors.New("modulus is not prime"))
+			}
+			t2 = t2.square().Mod(p)
+		}
+		bb := c
+		for j := types.Int(0); j < m-i-1; j++ {
+			bb = bb.square().Mod(p)
+		}
+		m, c = i, bb.square().Mod(p)
+		t, r = t.Multiply(c).Mod(p), r.Multiply(bb).Mod(p)
+	}
+	return r
+}
+
+// cipolla returns a square root of the residue a modulo the prime p as
+// (t + w)^((p+1)/2) in F_p(w) with w^2 = t^2 - a a non-residue.
+func cipolla(a, p *bigInteger) *bigInteger {
+	var w2 *bigInteger
+	t := nonResidue(p, func(t *bigInteger) *bigInteger {
+		w2 = t.square().Subtract(a).Mod(p)
+		return w2
+	})
+	// (x0 + x1 w)(y0 + y1 w) = (x0 y0 + x1 y1 w^2) + (x0 y1 + x1 y0) w
+	mul := func(x0, x1, y0, y1 *bigInteger) (*bigInteger, *bigInteger) {
+		return x0.Multiply(y0).Add(x1.Multiply(y1).Mod(p).Multiply(w2)).Mod(p), x0.Multiply(y1).Add(x1.Multiply(y0)).Mod(p)
+	}
+	e := p.add(1).shiftRight(1)
+	r0, r1 := ONE, ZERO
+	for i := e.BitLength() - 1; i >= 0; i-- {
+		r0, r1 = mul(r0, r1, r0, r1)
+		if e.testBit(i) {
+			r0, r1 = mul(r0, r1, t, ONE)
+		}
+	}
+	return r0
+}
+
+// nonResidue returns the least t >= 2 for which f(t) is a quadratic
+// non-residue modulo the prime p. For a composite p the search may fail, it
+// panics after a number of candidates that suffices for every prime under
+// the generalized Riemann hypothesis.
+func nonResidue(p *bigInteger, f func(t *bigInteger) *bigInteger) *bigInteger {
+	limit := 2*p.BitLength()*p.BitLength() + 10
+	for t := types.Long(2); t < limit.ToLong(); t++ {
+		bt := BigIntegerValueOf(t)
+		if Jacobi(f(bt), p) == -1 {
+			return bt
+		}
+	}
+	panic(errors.New("modulus is not prime"))
+}
+
+// smallerRoot returns min(r, m - r) for 0 <= r < m.
+func smallerRoot(r, m *bigInteger) *bigInteger {
+	if s := m.Subtract(r); s.CompareTo(r) < 0 {
+		return s
+	}
+	return r
+}
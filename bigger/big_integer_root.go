@@ -0,0 +1,213 @@
+package bigger
+
+import (
+	"errors"
+	"math"
+
+	"github.com/sineycoder/go-bigger/tool"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+var (
+	// quadratic residues modulo 64, 63, 65 and 11, a square must hit all four
+	p_SQUARE_RESIDUES_64 = residueTable(64)
+	p_SQUARE_RESIDUES_63 = residueTable(63)
+	p_SQUARE_RESIDUES_65 = residueTable(65)
+	p_SQUARE_RESIDUES_11 = residueTable(11)
+)
+
+func residueTable(m int) []bool {
+	t := make([]bool, m)
+	for i := 0; i < m; i++ {
+		t[i*i%m] = true
+	}
+	return t
+}
+
+// Root returns the n-th root of this bigInteger rounded toward zero. A negative
+// value has a root only for odd n, it is -Root(-b).
+func (b *bigInteger) Root(n types.Int) *bigInteger {
+	if n <= 0 {
+		panic(errors.New("non-positive root index"))
+	}
+	if b.signum < 0 {
+		if n%2 == 0 {
+			panic(errors.New("even root of a negative bigInteger"))
+		}
+		return b.negate().Root(n).negate()
+	}
+	if n == 1 || b.signum == 0 {
+		return b
+	}
+	if n == 2 {
+		return b.Sqrt()
+	}
+	return newMutableBigIntegerArray(b.mag).root(n).ToBigIntegerDefault()
+}
+
+// RootAndRemainder returns an array of two bigIntegers, the n-th root s of
+// this bigInteger as Root(n) does and the remainder this - s^n, which has the
+// sign of this bigInteger.
+func (b *bigInteger) RootAndRemainder(n types.Int) []*bigInteger {
+	s := b.Root(n)
+	return []*bigInteger{s, b.Subtract(s.Pow(n))}
+}
+
+// IsPerfectSquare returns true if this bigInteger is the square of an integer.
+func (b *bigInteger) IsPerfectSquare() bool {
+	if b.signum < 0 {
+		return false
+	}
+	if b.signum == 0 {
+		return true
+	}
+	if !p_SQUARE_RESIDUES_64[b.mag[len(b.mag)-1]&63] {
+		return false
+	}
+	r := b.remainderInt(63 * 65 * 11)
+	if !p_SQUARE_RESIDUES_63[r%63] || !p_SQUARE_RESIDUES_65[r%65] || !p_SQUARE_RESIDUES_11[r%11] {
+		return false
+	}
+	s := b.Sqrt()
+	return s.square().CompareTo(b) == 0
+}
+
+// IsPerfectPower finds the largest exponent e > 1 such that this bigInteger is
+// base^e. It returns this bigInteger, 1 and false when there is none. Values
+// of magnitude 0 and 1 are powers with any exponent and report false as well.
+// Negative values only admit odd exponents.
+func (b *bigInteger) IsPerfectPower() (*bigInteger, types.Int, bool) {
+	if b.compareMagnituteLong(1) <= 0 {
+		return b, 1, false
+	}
+	x, e := b.Abs(), types.Int(1)
+
+	// the exponent has to divide the multiplicity of every prime factor
+	g := x.getLowestSetBit()
+	for _, q := range primesUpTo(100)[1:] {
+		if g == 1 {
+			return b, 1, false
+		}
+		if x.remainderInt(q) == 0 {
+			v := types.Int(0)
+			for y := x; y.remainderInt(q) == 0; y = y.Divide(BigIntegerValueOf(q.ToLong())) {
+				v++
+			}
+			g = gcdInt(g, v)
+		}
+	}
+	if g == 1 {
+		return b, 1, false
+	}
+
+	for _, p := range primesUpTo(x.BitLength()) {
+		if p >= x.BitLength() {
+			break
+		}
+		if b.signum < 0 && p == 2 {
+			continue
+		}
+		for (g == 0 || g%p == 0) && x.BitLength() > p {
+			var r *bigInteger
+			if p == 2 {
+				if !x.IsPerfectSquare() {
+					break
+				}
+				r = x.Sqrt()
+			} else if r = x.Root(p); r.Pow(p).CompareTo(x) != 0 {
+				break
+			}
+			x, e = r, e*p
+			if g != 0 {
+				g /= p
+			}
+		}
+	}
+	if e == 1 {
+		return b, 1, false
+	}
+	if b.signum < 0 {
+		x = x.negate()
+	}
+	return x, e, true
+}
+
+// root returns the n-th root of m rounded down for n >= 3, by Newton
+// iteration x' = ((n-1)x + m/x^(n-1)) / n started from a float approximation.
+func (m *mutableBigInteger) root(n types.Int) *mutableBigInteger {
+	m.normalize()
+	bitLen := m.BitLength()
+	if bitLen == 0 {
+		return newMutableBigInteger(0)
+	}
+	if bitLen <= n.ToLong() {
+		return newMutableBigInteger(1)
+	}
+
+	// approximate the root of the leading 60 bits, shifted by a multiple of n
+	shift := tool.MaxLong(0, bitLen-60)
+	shift = (shift + n.ToLong() - 1) / n.ToLong() * n.ToLong()
+	top := newMutableBigIntegerObject(m)
+	top.safeRightShift(types.Int(shift))
+	top.normalize()
+	guess := types.Long(math.Pow(float64(top.toBigInteger(1).DoubleValue()), 1/float64(n))) + 1
+	xk := newMutableBigIntegerByBigInteger(BigIntegerValueOf(guess))
+	xk.safeLeftShift(types.Int(shift / n.ToLong()))
+
+	// the first step lands on or above the root, from there the iteration decreases
+	xk = m.rootStep(xk, n)
+	for {
+		xk1 := m.rootStep(xk, n)
+		if xk1.compare(xk) >= 0 {
+			return xk
+		}
+		xk = xk1
+	}
+}
+
+// rootStep returns ((n-1)x + m/x^(n-1)) / n rounded down.
+func (m *mutableBigInteger) rootStep(xk *mutableBigInteger, n types.Int) *mutableBigInteger {
+	x := xk.toBigInteger(1)
+	q := newMutableBigIntegerDefault()
+	m.divideRemainder(newMutableBigIntegerArray(x.Pow(n-1).mag), q, false)
+	q.add(newMutableBigIntegerArray(x.multiplyLong((n - 1).ToLong()).mag))
+	next := newMutableBigIntegerDefault()
+	q.divideOneWord(n, next)
+	next.normalize()
+	return next
+}
+
+// remainderInt returns |b| mod d for 0 < d < 2^31.
+func (b *bigInteger) remainderInt(d types.Int) types.Int {
+	dl, r := uint64(d), uint64(0)
+	for _, w := range b.mag {
+		r = (r<<32 | uint64(uint32(w))) % dl
+	}
+	return types.Int(r)
+}
+
+// primesUpTo returns the primes <= limit in ascending order.
+func primesUpTo(limit types.Int) []types.Int {
+	if limit < 2 {
+		return nil
+	}
+	composite := make([]bool, limit+1)
+	var primes []types.Int
+	for i := types.Int(2); i <= limit; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, i)
+		for j := i.ToLong() * i.ToLong(); j <= limit.ToLong(); j += i.ToLong() {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+func gcdInt(a, b types.Int) types.Int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,222 @@
+package bigger
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// bigRational is an exact fraction num/den. It is immutable and always
+// normalized: den is positive and shares no factor with num, zero is 0/1.
+type bigRational struct {
+	num *bigInteger
+	den *bigInteger
+}
+
+// NewBigRational returns num/den in lowest terms. It panics when den is zero.
+func NewBigRational(num, den *bigInteger) *bigRational {
+	if den.signum == 0 {
+		panic(errors.New("BigRational: zero denominator"))
+	}
+	if den.signum < 0 {
+		num, den = num.negate(), den.negate()
+	}
+	if g := num.Gcd(den); g.compareMagnituteLong(1) != 0 {
+		num, den = num.Divide(g), den.Divide(g)
+	}
+	return &bigRational{num: num, den: den}
+}
+
+// BigRationalValueOf returns num/den in lowest terms.
+func BigRationalValueOf(num, den types.Long) *bigRational {
+	return NewBigRational(BigIntegerValueOf(num), BigIntegerValueOf(den))
+}
+
+// NewBigRationalBigInteger returns the integer val as a fraction.
+func NewBigRationalBigInteger(val *bigInteger) *bigRational {
+	return &bigRational{num: val, den: ONE}
+}
+
+// NewBigRationalBigDecimal returns the exact value of val.
+func NewBigRationalBigDecimal(val *bigDecimal) *bigRational {
+	if val.scale <= 0 {
+		return NewBigRationalBigInteger(bigMultiplyPowerTenByBigInteger(val.inflated(), -val.scale))
+	}
+	return NewBigRational(val.inflated(), bigTenToThe(val.scale))
+}
+
+// NewBigRationalString parses a fraction "3/4", a mixed number "-1 1/2",
+// where the sign applies to the whole value, a decimal such as "2.5e-3" or a
+// repeating decimal such as "0.(3)".
+func NewBigRationalString(val string) *bigRational {
+	val = strings.TrimSpace(val)
+	if strings.ContainsAny(val, "(\u0305") {
+		return NewBigRationalRepeating(val)
+	}
+	slash := strings.IndexByte(val, '/')
+	if slash < 0 {
+		return NewBigRationalBigDecimal(NewBigDecimalString(val))
+	}
+	whole := ""
+	if space := strings.LastIndexByte(val[:slash], ' '); space >= 0 {
+		whole, val = strings.TrimSpace(val[:space]), val[space+1:]
+		slash -= space + 1
+		if val[0] == '-' || val[0] == '+' {
+			panic(errors.New("BigRational: signed fraction in a mixed number"))
+		}
+	}
+	f := NewBigRational(NewBigIntegerString(val[:slash]), NewBigIntegerString(val[slash+1:]))
+	if whole == "" {
+		return f
+	}
+	w := NewBigIntegerString(whole)
+	if w.signum < 0 || whole[0] == '-' {
+		f = f.Negate()
+	}
+	return NewBigRationalBigInteger(w).Add(f)
+}
+
+// Numerator returns the numerator, negative for a negative value.
+func (r *bigRational) Numerator() *bigInteger {
+	return r.num
+}
+
+// Denominator returns the positive denominator.
+func (r *bigRational) Denominator() *bigInteger {
+	return r.den
+}
+
+// Signum returns -1, 0 or 1 as the value is negative, zero or positive.
+func (r *bigRational) Signum() types.Int {
+	return r.num.signum
+}
+
+// IsInteger reports whether the denominator is one.
+func (r *bigRational) IsInteger() bool {
+	return r.den.compareMagnituteLong(1) == 0
+}
+
+// Negate returns -r.
+func (r *bigRational) Negate() *bigRational {
+	return &bigRational{num: r.num.negate(), den: r.den}
+}
+
+// Abs returns |r|.
+func (r *bigRational) Abs() *bigRational {
+	if r.num.signum >= 0 {
+		return r
+	}
+	return r.Negate()
+}
+
+// Inverse returns 1/r. It panics when r is zero.
+func (r *bigRational) Inverse() *bigRational {
+	if r.num.signum == 0 {
+		panic(errors.New("BigRational: division by zero"))
+	}
+	if r.num.signum < 0 {
+		return &bigRational{num: r.den.negate(), den: r.num.negate()}
+	}
+	return &bigRational{num: r.den, den: r.num}
+}
+
+// Add returns r + val. Only the gcd of the denominators is removed before
+// multiplying, which keeps the operands of the final gcd small (Knuth 4.5.1).
+func (r *bigRational) Add(val *bigRational) *bigRational {
+	if r.num.signum == 0 {
+		return val
+	}
+	if val.num.signum == 0 {
+		return r
+	}
+	g := r.den.Gcd(val.den)
+	if g.compareMagnituteLong(1) == 0 {
+		return &bigRational{num: r.num.Multiply(val.den).Add(val.num.Multiply(r.den)), den: r.den.Multiply(val.den)}
+	}
+	rd := r.den.Divide(g)
+	t := r.num.Multiply(val.den.Divide(g)).Add(val.num.Multiply(rd))
+	if t.signum == 0 {
+		return &bigRational{num: ZERO, den: ONE}
+	}
+	g2 := t.Gcd(g)
+	return &bigRational{num: t.Divide(g2), den: rd.Multiply(val.den.Divide(g2))}
+}
+
+// Subtract returns r - val.
+func (r *bigRational) Subtract(val *bigRational) *bigRational {
+	return r.Add(val.Negate())
+}
+
+// Multiply returns r val, cancelling across before multiplying.
+func (r *bigRational) Multiply(val *bigRational) *bigRational {
+	if r.num.signum == 0 || val.num.signum == 0 {
+		return &bigRational{num: ZERO, den: ONE}
+	}
+	g1, g2 := r.num.Gcd(val.den), val.num.Gcd(r.den)
+	return &bigRational{
+		num: r.num.Divide(g1).Multiply(val.num.Divide(g2)),
+		den: r.den.Divide(g2).Multiply(val.den.Divide(g1)),
+	}
+}
+
+// Divide returns r / val. It panics when val is zero.
+func (r *bigRational) Divide(val *bigRational) *bigRational {
+	return r.Multiply(val.Inverse())
+}
+
+// Pow returns r^exponent, a negative exponent inverts r first.
+func (r *bigRational) Pow(exponent types.Int) *bigRational {
+	if exponent < 0 {
+		r, exponent = r.Inverse(), -exponent
+	}
+	return &bigRational{num: r.num.Pow(exponent), den: r.den.Pow(exponent)}
+}
+
+// CompareTo returns -1, 0 or 1 as r is less than, equal to or greater than val.
+func (r *bigRational) CompareTo(val *bigRational) types.Int {
+	if r.num.signum != val.num.signum {
+		if r.num.signum < val.num.signum {
+			return -1
+		}
+		return 1
+	}
+	return r.num.Multiply(val.den).CompareTo(val.num.Multiply(r.den))
+}
+
+// Equals reports whether r and val are the same number.
+func (r *bigRational) Equals(val *bigRational) bool {
+	return r.num.CompareTo(val.num) == 0 && r.den.CompareTo(val.den) == 0
+}
+
+// Floor returns the greatest integer not above r.
+func (r *bigRational) Floor() *bigInteger {
+	return r.Round(ROUND_FLOOR)
+}
+
+// Ceil returns the least integer not below r.
+func (r *bigRational) Ceil() *bigInteger {
+	return r.Round(ROUND_CEILING)
+}
+
+// Round returns r rounded to an integer with the roundingMode. ROUND_UNNECESSARY
+// panics when r is not an integer.
+func (r *bigRational) Round(roundingMode RoundingMode) *bigInteger {
+	if r.IsInteger() {
+		return r.num
+	}
+	return r.BigDecimal(0, roundingMode).inflated()
+}
+
+// BigDecimal returns r with the given scale, rounded with the roundingMode.
+func (r *bigRational) BigDecimal(scale types.Int, roundingMode RoundingMode) *bigDecimal {
+	return newBigDecimalByBigInteger2(r.num, 0).Divide(newBigDecimalByBigInteger2(r.den, 0), scale, roundingMode)
+}
+
+// String returns "num/den", or just "num" for an integer.
+func (r *bigRational) String() string {
+	if r.IsInteger() {
+		return r.num.String()
+	}
+	return r.num.String() + "/" + r.den.String()
+}
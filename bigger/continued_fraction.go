@@ -0,0 +1,151 @@
+package bigger
+
+import (
+	"errors"
+)
+
+// ContinuedFraction returns the terms [a0; a1, a2, ...] of the regular
+// continued fraction of r, a0 = floor(r) and ai >= 1 for i > 0. The last term
+// is above one unless r is an integer, which makes the expansion unique.
+func (r *bigRational) ContinuedFraction() []*bigInteger {
+	var terms []*bigInteger
+	for c := NewConvergents(r); c.next(); {
+		terms = append(terms, c.term)
+	}
+	return terms
+}
+
+// ContinuedFraction returns the regular continued fraction of the exact value
+// of b.
+func (b *bigDecimal) ContinuedFraction() []*bigInteger {
+	return NewBigRationalBigDecimal(b).ContinuedFraction()
+}
+
+// FromContinuedFraction returns the value of [a0; a1, a2, ...]. Every term
+// after the first must be positive.
+func FromContinuedFraction(terms []*bigInteger) *bigRational {
+	if len(terms) == 0 {
+		panic(errors.New("empty continued fraction"))
+	}
+	// p/q accumulates from the last term backwards
+	p, q := terms[len(terms)-1], ONE
+	for i := len(terms) - 2; i >= 0; i-- {
+		if terms[i+1].signum <= 0 {
+			panic(errors.New("continued fraction term not positive"))
+		}
+		p, q = terms[i].Multiply(p).Add(q), p
+	}
+	return &bigRational{num: p, den: q}
+}
+
+// convergents iterates over the convergents p/q of a continued fraction,
+// which are in lowest terms and alternate around the value.
+type convergents struct {
+	num, den *bigInteger // the remaining complete quotient num/den
+	p0, q0   *bigInteger // the convergent before the last
+	p1, q1   *bigInteger // the last convergent
+	term     *bigInteger // the last term
+}
+
+// NewConvergents starts the convergents of x.
+func NewConvergents(x *bigRational) *convergents {
+	return &convergents{num: x.num, den: x.den, p0: ZERO, q0: ONE, p1: ONE, q1: ZERO}
+}
+
+// Next returns the next convergent and true, or nil and false after the last
+// one, which is x.
+func (c *convergents) Next() (*bigRational, bool) {
+	if !c.next() {
+		return nil, false
+	}
+	return &bigRational{num: c.p1, den: c.q1}, true
+}
+
+// Term returns the continued fraction term of the convergent returned last.
+func (c *convergents) Term() *bigInteger {
+	return c.term
+}
+
+func (c *convergents) next() bool {
+	if c.den.signum == 0 {
+		return false
+	}
+	a, rem := floorDivide(c.num, c.den)
+	c.num, c.den, c.term = c.den, rem, a
+	c.p0, c.p1 = c.p1, a.Multiply(c.p1).Add(c.p0)
+	c.q0, c.q1 = c.q1, a.Multiply(c.q1).Add(c.q0)
+	return true
+}
+
+// BestApproximation returns the fraction closest to x among those with a
+// denominator of at most maxDenominator, the one with the smaller denominator
+// on a tie. It is the last convergent within the limit or the largest
+// semiconvergent after it.
+func BestApproximation(x *bigRational, maxDenominator *bigInteger) *bigRational {
+	if maxDenominator.signum <= 0 {
+		panic(errors.New("maxDenominator must be positive"))
+	}
+	if x.den.CompareTo(maxDenominator) <= 0 {
+		return x
+	}
+	c := NewConvergents(x)
+	for {
+		// x has a denominator above the limit, so the loop ends before x
+		a, _ := floorDivide(c.num, c.den)
+		if a.Multiply(c.q1).Add(c.q0).CompareTo(maxDenominator) > 0 {
+			break
+		}
+		c.next()
+	}
+	k, _ := floorDivide(maxDenominator.Subtract(c.q0), c.q1)
+	semi := &bigRational{num: k.Multiply(c.p1).Add(c.p0), den: k.Multiply(c.q1).Add(c.q0)}
+	last := &bigRational{num: c.p1, den: c.q1}
+	d := last.Subtract(x).Abs().CompareTo(semi.Subtract(x).Abs())
+	if d < 0 || d == 0 && last.den.CompareTo(semi.den) <= 0 {
+		return last
+	}
+	return semi
+}
+
+// SimplestBetween returns the fraction with the smallest denominator in the
+// closed interval between a and b, the one with the smallest numerator
+// magnitude among those. It is the first fraction of the interval met on the
+// way down the Stern-Brocot tree.
+func SimplestBetween(a, b *bigRational) *bigRational {
+	if a.CompareTo(b) > 0 {
+		a, b = b, a
+	}
+	if b.num.signum < 0 {
+		return SimplestBetween(b.Negate(), a.Negate()).Negate()
+	}
+	if a.num.signum <= 0 {
+		return &bigRational{num: ZERO, den: ONE}
+	}
+	// 0 < a <= b, the terms common to both expansions are taken until an
+	// integer lies in between
+	var terms []*bigInteger
+	for {
+		fa, ra := floorDivide(a.num, a.den)
+		fb, _ := floorDivide(b.num, b.den)
+		if ra.signum == 0 || fa.Add(ONE).CompareTo(fb) <= 0 {
+			// ceil(a) <= b
+			if ra.signum != 0 {
+				fa = fa.Add(ONE)
+			}
+			terms = append(terms, fa)
+			return FromContinuedFraction(terms)
+		}
+		terms = append(terms, fa)
+		i := NewBigRationalBigInteger(fa)
+		a, b = b.Subtract(i).Inverse(), a.Subtract(i).Inverse()
+	}
+}
+
+// floorDivide returns floor(n / d) and n - d floor(n / d) for d > 0.
+func floorDivide(n, d *bigInteger) (*bigInteger, *bigInteger) {
+	qr := n.DivideAndRemainder(d)
+	if qr[1].signum < 0 {
+		return qr[0].add(-1), qr[1].Add(d)
+	}
+	return qr[0], qr[1]
+}
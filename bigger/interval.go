@@ -0,0 +1,243 @@
+package bigger
+
+import (
+	"errors"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// interval is the closed interval [lo, hi] of bigDecimals. The operations
+// round the lower bound of their result with ROUND_FLOOR and the upper bound
+// with ROUND_CEILING to the given scale, so the true result always lies
+// inside. Bounds already within the scale are kept exact. A nil bound is
+// unbounded, such intervals only come from DivideExtended.
+type interval struct {
+	lo *bigDecimal
+	hi *bigDecimal
+}
+
+// NewInterval returns [lo, hi]. It panics when lo > hi.
+func NewInterval(lo, hi *bigDecimal) *interval {
+	if lo.CompareTo(hi) > 0 {
+		panic(errors.New("Interval: lower bound above upper bound"))
+	}
+	return &interval{lo: lo, hi: hi}
+}
+
+// NewIntervalPoint returns [x, x].
+func NewIntervalPoint(x *bigDecimal) *interval {
+	return &interval{lo: x, hi: x}
+}
+
+// Lower returns the lower bound, nil when unbounded.
+func (v *interval) Lower() *bigDecimal {
+	return v.lo
+}
+
+// Upper returns the upper bound, nil when unbounded.
+func (v *interval) Upper() *bigDecimal {
+	return v.hi
+}
+
+// IsBounded reports whether both bounds are finite.
+func (v *interval) IsBounded() bool {
+	return v.lo != nil && v.hi != nil
+}
+
+// Width returns hi - lo.
+func (v *interval) Width() *bigDecimal {
+	v.checkBounded()
+	return v.hi.Subtract(v.lo)
+}
+
+// Midpoint returns (lo + hi) / 2, which is exact.
+func (v *interval) Midpoint() *bigDecimal {
+	v.checkBounded()
+	return v.lo.Add(v.hi).Multiply(valueOf(5, 1))
+}
+
+// Contains reports whether lo <= x <= hi.
+func (v *interval) Contains(x *bigDecimal) bool {
+	return (v.lo == nil || v.lo.CompareTo(x) <= 0) && (v.hi == nil || x.CompareTo(v.hi) <= 0)
+}
+
+// ContainsZero reports whether zero lies in the interval.
+func (v *interval) ContainsZero() bool {
+	return v.Contains(zeroValueOf(0))
+}
+
+// ContainsInterval reports whether val is a subset of the interval.
+func (v *interval) ContainsInterval(val *interval) bool {
+	return (v.lo == nil || val.lo != nil && v.lo.CompareTo(val.lo) <= 0) &&
+		(v.hi == nil || val.hi != nil && val.hi.CompareTo(v.hi) <= 0)
+}
+
+// Negate returns [-hi, -lo].
+func (v *interval) Negate() *interval {
+	v.checkBounded()
+	return &interval{lo: v.hi.Negate(), hi: v.lo.Negate()}
+}
+
+// Add returns [lo + val.lo, hi + val.hi].
+func (v *interval) Add(val *interval, scale types.Int) *interval {
+	v.checkBounded()
+	val.checkBounded()
+	return newIntervalRounded(v.lo.Add(val.lo), v.hi.Add(val.hi), scale)
+}
+
+// Subtract returns [lo - val.hi, hi - val.lo].
+func (v *interval) Subtract(val *interval, scale types.Int) *interval {
+	v.checkBounded()
+	val.checkBounded()
+	return newIntervalRounded(v.lo.Subtract(val.hi), v.hi.Subtract(val.lo), scale)
+}
+
+// Multiply returns the hull of the products of the bounds.
+func (v *interval) Multiply(val *interval, scale types.Int) *interval {
+	v.checkBounded()
+	val.checkBounded()
+	lo := v.lo.Multiply(val.lo)
+	hi := lo
+	for _, p := range []*bigDecimal{v.lo.Multiply(val.hi), v.hi.Multiply(val.lo), v.hi.Multiply(val.hi)} {
+		if p.CompareTo(lo) < 0 {
+			lo = p
+		}
+		if p.CompareTo(hi) > 0 {
+			hi = p
+		}
+	}
+	return newIntervalRounded(lo, hi, scale)
+}
+
+// Divide returns the hull of the quotients of the bounds. It panics when val
+// contains zero, see DivideExtended.
+func (v *interval) Divide(val *interval, scale types.Int) *interval {
+	v.checkBounded()
+	val.checkBounded()
+	if val.ContainsZero() {
+		panic(errors.New("Interval: division by an interval containing zero"))
+	}
+	var lo, hi *bigDecimal
+	for _, x := range []*bigDecimal{v.lo, v.hi} {
+		for _, y := range []*bigDecimal{val.lo, val.hi} {
+			if q := x.Divide(y, scale, ROUND_FLOOR); lo == nil || q.CompareTo(lo) < 0 {
+				lo = q
+			}
+			if q := x.Divide(y, scale, ROUND_CEILING); hi == nil || q.CompareTo(hi) > 0 {
+				hi = q
+			}
+		}
+	}
+	return &interval{lo: lo, hi: hi}
+}
+
+// DivideExtended returns {x / y : x in the interval, y in val, y != 0} as
+// zero, one or two intervals in increasing order. When val contains zero the
+// pieces are unbounded on the side of the pole, and the whole line when the
+// interval contains zero as well.
+func (v *interval) DivideExtended(val *interval, scale types.Int) []*interval {
+	v.checkBounded()
+	val.checkBounded()
+	if !val.ContainsZero() {
+		return []*interval{v.Divide(val, scale)}
+	}
+	c, d := val.lo.signum(), val.hi.signum()
+	if c == 0 && d == 0 {
+		return []*interval{}
+	}
+	if v.ContainsZero() {
+		return []*interval{{}}
+	}
+	// x keeps one sign, the bound nearest zero gives the finite ends
+	x := v.lo
+	if v.lo.signum() < 0 {
+		x = v.hi
+	}
+	var left, right *interval
+	if c < 0 {
+		// y in [val.lo, 0)
+		if x.signum() < 0 {
+			right = &interval{lo: x.Divide(val.lo, scale, ROUND_FLOOR)}
+		} else {
+			left = &interval{hi: x.Divide(val.lo, scale, ROUND_CEILING)}
+		}
+	}
+	if d > 0 {
+		// y in (0, val.hi]
+		if x.signum() < 0 {
+			left = &interval{hi: x.Divide(val.hi, scale, ROUND_CEILING)}
+		} else {
+			right = &interval{lo: x.Divide(val.hi, scale, ROUND_FLOOR)}
+		}
+	}
+	pieces := []*interval{}
+	for _, p := range []*interval{left, right} {
+		if p != nil {
+			pieces = append(pieces, p)
+		}
+	}
+	return pieces
+}
+
+// Sqrt returns [sqrt(lo), sqrt(hi)], with a negative lower bound taken as
+// zero. It panics when hi is negative.
+func (v *interval) Sqrt(scale types.Int) *interval {
+	v.checkBounded()
+	if v.hi.signum() < 0 {
+		panic(errors.New("Interval: square root of a negative interval"))
+	}
+	lo := zeroValueOf(0)
+	if v.lo.signum() > 0 {
+		lo = sqrtAtScale(v.lo, scale, false)
+	}
+	return &interval{lo: lo, hi: sqrtAtScale(v.hi, scale, true)}
+}
+
+// String returns "[lo, hi]" with "-inf" and "+inf" for unbounded sides.
+func (v *interval) String() string {
+	lo, hi := "-inf", "+inf"
+	if v.lo != nil {
+		lo = v.lo.String()
+	}
+	if v.hi != nil {
+		hi = v.hi.String()
+	}
+	return "[" + lo + ", " + hi + "]"
+}
+
+func (v *interval) checkBounded() {
+	if !v.IsBounded() {
+		panic(errors.New("Interval: unbounded interval"))
+	}
+}
+
+// newIntervalRounded returns [lo, hi] widened outward to the scale.
+func newIntervalRounded(lo, hi *bigDecimal, scale types.Int) *interval {
+	if lo.scale > scale {
+		lo = lo.SetScale(scale, ROUND_FLOOR)
+	}
+	if hi.scale > scale {
+		hi = hi.SetScale(scale, ROUND_CEILING)
+	}
+	return &interval{lo: lo, hi: hi}
+}
+
+// sqrtAtScale returns the square root of x >= 0 at the scale, rounded down or,
+// when ceiling is set, up. The floor of sqrt(x 10^(2 scale)) is the integer
+// root of its floor.
+func sqrtAtScale(x *bigDecimal, scale types.Int, ceiling bool) *bigDecimal {
+	u, shift := x.inflated(), 2*scale-x.scale
+	exact := true
+	if shift >= 0 {
+		u = bigMultiplyPowerTenByBigInteger(u, shift)
+	} else {
+		qr := u.DivideAndRemainder(bigTenToThe(-shift))
+		u, exact = qr[0], qr[1].signum == 0
+	}
+	rr := u.SqrtAndRemainder()
+	r := rr[0]
+	if ceiling && (!exact || rr[1].signum != 0) {
+		r = r.add(1)
+	}
+	return newBigDecimalByBigInteger2(r, scale)
+}
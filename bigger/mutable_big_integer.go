@@ -25,6 +25,7 @@ type mutableBigInteger struct {
 	value  []types.Int
 	intLen types.Int
 	offset types.Int
+	ws     *workspace // source of scratch buffers, nil for the shared pools
 }
 
 func (m *mutableBigInteger) Divide(b *mutableBigInteger, quotient *mutableBigInteger) *mutableBigInteger {
@@ -48,9 +49,11 @@ func (m *mutableBigInteger) divide(v types.Long, quotient *mutableBigInteger) ty
 	if d == 0 {
 		return m.divideOneWord(v.ToInt(), quotient).ToLong() & p_LONG_MASK
 	} else {
-		return m.divideLongMagnitude(v, quotient).toLong()
+		rem := m.divideLongMagnitude(v, quotient)
+		r := rem.toLong()
+		m.ws.putInts(rem.value)
+		return r
 	}
-	return 0
 }
 
 func (m *mutableBigInteger) divideRemainder(b *mutableBigInteger, quotient *mutableBigInteger, needRemainder bool) *mutableBigInteger {
@@ -134,7 +137,7 @@ func (m *mutableBigInteger) getLowestSetBit() types.Int {
 		return -1
 	}
 	var j, b types.Int
-	for j = m.intLen - 1; (j > 0) && (m.value[j+m.offset] == 0); j++ {
+	for j = m.intLen - 1; (j > 0) && (m.value[j+m.offset] == 0); j-- {
 	}
 	b = m.value[j+m.offset]
 	if b == 0 {
@@ -244,17 +247,17 @@ func (m *mutableBigInteger) leftShift(n types.Int) {
 	if types.Int(len(m.value)) < newLen {
 		result := make([]types.Int, newLen)
 		for i := types.Int(0); i < m.intLen; i++ {
-			result[i] = m.value[m.offset+1]
+			result[i] = m.value[m.offset+i]
 		}
 		m.setValue(result, newLen)
 	} else if types.Int(len(m.value))-m.offset >= newLen {
 		for i := types.Int(0); i < newLen-m.intLen; i++ {
-			m.value[m.offset+m.intLen+1] = 0
+			m.value[m.offset+m.intLen+i] = 0
 		}
 	} else {
 		// Must use space on left
 		for i := types.Int(0); i < m.intLen; i++ {
-			m.value[i] = m.value[m.offset+1]
+			m.value[i] = m.value[m.offset+i]
 		}
 		for i := m.intLen; i < newLen; i++ {
 			m.value[i] = 0
@@ -284,17 +287,23 @@ func (m *mutableBigInteger) divideMagnitude(div *mutableBigInteger, quotient *mu
 	dlen := div.intLen
 	var divisor []types.Int
 	var rem *mutableBigInteger
+	// the remainder escapes only when it is asked for
+	var remarr []types.Int
+	divisor = m.ws.getInts(dlen)
+	defer m.ws.putInts(divisor)
+	if !needRemainder {
+		defer func() { m.ws.putInts(remarr) }()
+	}
 	if shift > 0 {
-		divisor = make([]types.Int, dlen)
 		copyAndShift(div.value, div.offset, dlen, divisor, 0, shift)
 		if NumberOfLeadingZeros(m.value[m.offset]) >= shift {
-			remarr := make([]types.Int, m.intLen+1)
+			remarr = m.newScratch(m.intLen+1, needRemainder)
 			rem = newMutableBigIntegerArray(remarr)
 			rem.intLen = m.intLen
 			rem.offset = 1
 			copyAndShift(m.value, m.offset, m.intLen, remarr, 1, shift)
 		} else {
-			remarr := make([]types.Int, m.intLen+2)
+			remarr = m.newScratch(m.intLen+2, needRemainder)
 			rem = newMutableBigIntegerArray(remarr)
 			rem.intLen = m.intLen + 1
 			rem.offset = 1
@@ -310,8 +319,9 @@ func (m *mutableBigInteger) divideMagnitude(div *mutableBigInteger, quotient *mu
 			remarr[m.intLen+1] = c << shift
 		}
 	} else {
-		divisor = tool.CopyRange(div.value, m.offset, m.offset+m.intLen)
-		rem = newMutableBigIntegerArray(make([]types.Int, m.intLen+1))
+		copy(divisor, div.value[div.offset:div.offset+div.intLen])
+		remarr = m.newScratch(m.intLen+1, needRemainder)
+		rem = newMutableBigIntegerArray(remarr)
 		tool.Arraycopy(m.value, m.offset, rem.value, 1, m.intLen)
 		rem.intLen = m.intLen
 		rem.offset = 1
@@ -467,6 +477,39 @@ func (m *mutableBigInteger) divideMagnitude(div *mutableBigInteger, quotient *mu
 	}
 }
 
+// scratchCopy returns a copy of src whose array is drawn from the scratch
+// buffers of m. The copy draws its own scratch buffers from there as well.
+func (m *mutableBigInteger) scratchCopy(src *mutableBigInteger) *mutableBigInteger {
+	c := m.ws.newMutable(m.ws.getInts(src.intLen))
+	copy(c.value, src.value[src.offset:src.offset+src.intLen])
+	return c
+}
+
+// scratchZero returns a zero whose array is drawn from the scratch buffers of m.
+func (m *mutableBigInteger) scratchZero() *mutableBigInteger {
+	z := m.ws.newMutable(m.ws.getInts(1))
+	z.intLen = 0
+	return z
+}
+
+// release hands the array back to the scratch buffers, m must not be used afterwards.
+func (m *mutableBigInteger) release() {
+	m.ws.putInts(m.value)
+	m.value = nil
+	m.intLen = 0
+	m.offset = 0
+	m.ws.putMutable(m)
+}
+
+// newScratch returns a zeroed array of length n, drawn from the scratch
+// buffers unless it escapes as a result.
+func (m *mutableBigInteger) newScratch(n types.Int, escapes bool) []types.Int {
+	if escapes {
+		return make([]types.Int, n)
+	}
+	return m.ws.getInts(n)
+}
+
 func (m *mutableBigInteger) mulsub(q []types.Int, a []types.Int, x types.Int, length types.Int, offset types.Int) types.Int {
 	xLong := x.ToLong() & p_LONG_MASK
 	carry := types.Long(0)
@@ -584,9 +627,9 @@ func (m *mutableBigInteger) DivideAndRemainderBurnikelZiegler(b *mutableBigInteg
 		n = j * m2            // step 2b: block length in 32-bit units
 		n32 = 32 * n.ToLong()
 		sigma = tool.MaxLong(0, n32-b.BitLength()).ToInt()
-		bShifted := newMutableBigIntegerObject(b)
+		bShifted := m.scratchCopy(b)
 		bShifted.safeLeftShift(sigma)
-		ashifted := newMutableBigIntegerObject(m)
+		ashifted := m.scratchCopy(m)
 		ashifted.safeLeftShift(sigma)
 
 		t := ((ashifted.BitLength() + n32) / n32).ToInt()
@@ -598,17 +641,23 @@ func (m *mutableBigInteger) DivideAndRemainderBurnikelZiegler(b *mutableBigInteg
 		z := ashifted.getBlock(t-2, t, n)
 		z.addDisjoint(a1, n)
 
-		qi := newMutableBigIntegerDefault()
+		qi := m.scratchZero()
 		var ri *mutableBigInteger
 		for i := t - 2; i > 0; i-- {
 			ri = z.divide2n1n(bShifted, qi)
+			z.release()
 			z = ashifted.getBlock(i-1, t, n)
 			z.addDisjoint(ri, n)
+			ri.release()
 			quotient.addShifted(qi, i*n)
 		}
 
 		ri = z.divide2n1n(bShifted, qi)
 		quotient.add(qi)
+		z.release()
+		qi.release()
+		ashifted.release()
+		bShifted.release()
 
 		ri.rightShift(sigma)
 		return ri
@@ -631,7 +680,7 @@ func (m *mutableBigInteger) safeLeftShift(n types.Int) {
 func (m *mutableBigInteger) getBlock(index types.Int, numBlocks types.Int, blockLength types.Int) *mutableBigInteger {
 	blockStart := index * blockLength
 	if blockStart >= m.intLen {
-		return newMutableBigIntegerDefault()
+		return m.scratchZero()
 	}
 
 	var blockEnd types.Int
@@ -641,11 +690,12 @@ func (m *mutableBigInteger) getBlock(index types.Int, numBlocks types.Int, block
 		blockEnd = (index + 1) * blockLength
 	}
 	if blockEnd > m.intLen {
-		return newMutableBigIntegerDefault()
+		return m.scratchZero()
 	}
 
-	newVal := tool.CopyRange(m.value, m.offset+m.intLen-blockEnd, m.offset+m.intLen-blockStart)
-	return newMutableBigIntegerArray(newVal)
+	block := m.ws.newMutable(m.ws.getInts(blockEnd - blockStart))
+	copy(block.value, m.value[m.offset+m.intLen-blockEnd:m.offset+m.intLen-blockStart])
+	return block
 }
 
 func (m *mutableBigInteger) addDisjoint(addend *mutableBigInteger, n types.Int) {
@@ -694,23 +744,26 @@ func (m *mutableBigInteger) IsZero() bool {
 }
 
 func (m *mutableBigInteger) divide2n1n(b *mutableBigInteger, quotient *mutableBigInteger) *mutableBigInteger {
-	n := m.intLen
+	n := b.intLen
 
 	if n%2 != 0 || n < p_BURNIKEL_ZIEGLER_THRESHOLD {
 		return m.divideKnuth(b, quotient, true)
 	}
 
-	aUpper := newMutableBigIntegerObject(m)
+	aUpper := m.scratchCopy(m)
 	aUpper.safeRightShift(32 * (n / 2))
 	m.keepLower(n / 2)
 
-	q1 := newMutableBigIntegerDefault()
+	q1 := m.scratchZero()
 	r1 := aUpper.divide3n2n(b, q1)
+	aUpper.release()
 
 	m.addDisjoint(r1, n/2)
+	r1.release()
 	r2 := m.divide3n2n(b, quotient)
 
 	quotient.addDisjoint(q1, n/2)
+	q1.release()
 	return r2
 }
 
@@ -737,17 +790,20 @@ func (m *mutableBigInteger) keepLower(n types.Int) {
 func (m *mutableBigInteger) divide3n2n(b *mutableBigInteger, quotient *mutableBigInteger) *mutableBigInteger {
 	n := b.intLen / 2
 
-	a12 := newMutableBigIntegerObject(m)
+	a12 := m.scratchCopy(m)
 	a12.safeRightShift(32 * n)
 
-	b1 := newMutableBigIntegerObject(b)
+	b1 := m.scratchCopy(b)
+	defer b1.release()
 	b1.safeRightShift(n * 32)
 	b2 := b.getLower(n)
 
 	var r, d *mutableBigInteger
 	if m.compareShifted(b, n) < 0 {
 		r = a12.divide2n1n(b1, quotient)
-		d = newMutableBigIntegerByBigInteger(quotient.ToBigIntegerDefault().Multiply(b2))
+		a12.release()
+		// the product is a fresh array and d is only read
+		d = newMutableBigIntegerArray(quotient.ToBigIntegerDefault().Multiply(b2).mag)
 	} else {
 		quotient.ones(n)
 		a12.add(b1)
@@ -861,7 +917,7 @@ func (m *mutableBigInteger) add(addend *mutableBigInteger) {
 
 	for x > 0 {
 		x--
-		if carry == 0 && tool.IntEqual(result, m.value) && rstart == (x+m.offset) {
+		if carry == 0 && sameArray(result, m.value) && rstart == (x+m.offset) {
 			return
 		}
 		sum = (m.value[x+m.offset].ToLong() & p_LONG_MASK) + carry
@@ -944,7 +1000,8 @@ func (m *mutableBigInteger) subtract(b *mutableBigInteger) types.Int {
 }
 
 func (m *mutableBigInteger) addLower(addend *mutableBigInteger, n types.Int) {
-	a := newMutableBigIntegerObject(addend)
+	a := m.scratchCopy(addend)
+	defer a.release()
 	if a.offset+a.intLen >= n {
 		a.offset = a.offset + a.intLen - n
 		a.intLen = n
@@ -996,7 +1053,7 @@ func (m *mutableBigInteger) addShifted(addend *mutableBigInteger, n types.Int) {
 
 	for x > 0 {
 		x--
-		if carry == 0 && tool.IntEqual(result, m.value) && rstart == (x+m.offset) {
+		if carry == 0 && sameArray(result, m.value) && rstart == (x+m.offset) {
 			return
 		}
 		sum = (m.value[x+m.offset].ToLong() & p_LONG_MASK) + carry
@@ -1101,12 +1158,14 @@ func (m *mutableBigInteger) sqrt() *mutableBigInteger {
 	if m.IsZero() {
 		return newMutableBigInteger(0)
 	} else if len(m.value) == 1 && (m.value[0].ToLong()&p_LONG_MASK) < 4 {
-		return mutable_one
+		return newMutableBigInteger(1)
 	}
 
 	if m.BitLength() <= 63 {
-		v := newBigInteger(m.value, 1).LongValueExact()
-		xk := types.Long(math.Floor(math.Sqrt(float64(v))))
+		v := m.toBigInteger(1).LongValueExact()
+		// one past the floor of the rounded root stays above the true root,
+		// which Newton's iteration needs to converge from
+		xk := types.Long(math.Floor(math.Sqrt(float64(v)))) + 1
 
 		for {
 			xk1 := (xk + v/xk) / 2
@@ -1129,23 +1188,30 @@ func (m *mutableBigInteger) sqrt() *mutableBigInteger {
 			shift++
 		}
 
-		xk := newMutableBigIntegerObject(m)
+		xk := m.scratchCopy(m)
 		xk.rightShift(shift)
 		xk.normalize()
 
-		d := newBigInteger(xk.value, 1).DoubleValue()
-		bi := BigIntegerValueOf(types.Long(math.Ceil(math.Sqrt(float64(d)))))
-		xk = newMutableBigIntegerArray(bi.mag)
+		d := xk.toBigInteger(1).DoubleValue()
+		xk.release()
+		// the shifted out bits and the rounding of d are worth less than one
+		// at the root, so ceil(sqrt(d)) + 1 over-estimates sqrt(m) / 2^(shift/2)
+		bi := BigIntegerValueOf(types.Long(math.Ceil(math.Sqrt(float64(d)))) + 1)
+		xk = newMutableBigIntegerByBigInteger(bi) // small values are cached, never shift them in place
 
 		xk.leftShift(shift / 2)
 
-		xk1 := newMutableBigIntegerDefault()
+		// the root escapes, the quotients and remainders are scratch
+		xk1 := m.scratchZero()
 		for {
-			m.divideRemainder(xk, xk1, false)
+			if r := m.divideRemainder(xk, xk1, false); r != nil && r != m {
+				r.release()
+			}
 			xk1.add(xk)
 			xk1.rightShift(1)
 
 			if xk1.compare(xk) >= 0 {
+				xk1.release()
 				return xk
 			}
 
@@ -1167,7 +1233,7 @@ func (m *mutableBigInteger) copyValue(src *mutableBigInteger) {
 }
 
 func (m *mutableBigInteger) divideLongMagnitude(ldivisor types.Long, quotient *mutableBigInteger) *mutableBigInteger {
-	rem := newMutableBigIntegerArray(make([]types.Int, m.intLen+1))
+	rem := newMutableBigIntegerArray(m.ws.getInts(m.intLen + 1))
 	tool.Arraycopy(m.value, m.offset, rem.value, 1, m.intLen)
 	rem.intLen = m.intLen
 	rem.offset = 1
@@ -1262,7 +1328,7 @@ func (m *mutableBigInteger) divideLongMagnitude(ldivisor types.Long, quotient *m
 func (m *mutableBigInteger) mulsubLong(q []types.Int, dh types.Int, dl types.Int, x types.Int, offset types.Int) types.Int {
 	xLong := x.ToLong() & p_LONG_MASK
 	offset += 2
-	product := (dl.ToLong() & p_LONG_MASK) & xLong
+	product := (dl.ToLong() & p_LONG_MASK) * xLong
 	difference := q[offset].ToLong() - product
 	q[offset] = difference.ToInt()
 	offset--
@@ -1285,6 +1351,7 @@ func (m *mutableBigInteger) divaddLong(dh, dl types.Int, result []types.Int, off
 	var carry types.Long
 	sum := (dl.ToLong() & p_LONG_MASK) + (result[1+offset].ToLong() & p_LONG_MASK)
 	result[1+offset] = sum.ToInt()
+	carry = sum.ShiftR(32)
 
 	sum = (dh.ToLong() & p_LONG_MASK) + (result[offset].ToLong() & p_LONG_MASK) + carry
 	result[offset] = sum.ToInt()
@@ -1411,6 +1478,11 @@ func (m *mutableBigInteger) toCompactValue(sign types.Int) types.Long {
 	}
 }
 
+// sameArray reports whether a and b share the same backing storage.
+func sameArray(a, b []types.Int) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
 func unsignedLongCompare(one, two types.Long) bool {
 	return (one + MIN_INT64) > (two + MIN_INT64)
 }
@@ -0,0 +1,69 @@
+package bigger
+
+import (
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Opt-in parallel evaluation of the recursive algorithms.
+//
+// Karatsuba, Toom-Cook 3, the NTT and the recursive radix conversion all
+// split their work into independent subproblems. When parallelism is enabled
+// and an operand is at least p_PARALLEL_THRESHOLD ints long, the subproblems
+// are handed to goroutines while a slot of the bounded pool is free, and are
+// evaluated inline otherwise, so the pool can never deadlock. Burnikel-Ziegler
+// division has sequentially dependent blocks, it gets its speed up from the
+// multiplications it performs.
+
+var (
+	// p_PARALLEL_THRESHOLD is the length in ints from which subproblems are
+	// handed to other goroutines.
+	p_PARALLEL_THRESHOLD types.Int = 512
+	// parallelSlots holds one token for every running helper goroutine,
+	// nil means that parallelism is disabled.
+	parallelSlots chan struct{}
+)
+
+// SetParallelism sets the number of goroutines, the calling one included, that
+// a single operation on huge numbers may use. workers <= 1 disables parallel
+// evaluation, which is the default. It is not safe to call concurrently with
+// arithmetic.
+func SetParallelism(workers int) {
+	if workers <= 1 {
+		parallelSlots = nil
+		return
+	}
+	parallelSlots = make(chan struct{}, workers-1)
+}
+
+// Parallelism returns the number of goroutines set by SetParallelism, 1 when
+// parallel evaluation is disabled.
+func Parallelism() int {
+	return cap(parallelSlots) + 1
+}
+
+// fork starts f, in another goroutine if the size qualifies and a pool slot is
+// free. The returned join function waits for f and re-raises its panic.
+func fork(size types.Int, f func()) (join func()) {
+	slots := parallelSlots
+	if slots != nil && size >= p_PARALLEL_THRESHOLD {
+		select {
+		case slots <- struct{}{}:
+			done := make(chan interface{}, 1)
+			go func() {
+				defer func() {
+					<-slots
+					done <- recover()
+				}()
+				f()
+			}()
+			return func() {
+				if e := <-done; e != nil {
+					panic(e)
+				}
+			}
+		default:
+		}
+	}
+	f()
+	return func() {}
+}
@@ -0,0 +1,543 @@
+package bigger
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/sineycoder/go-bigger/tool"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// polynomial is a dense univariate polynomial with bigInteger coefficients.
+// It is immutable, coeffs[i] is the coefficient of x^i and the last one is
+// not zero, the zero polynomial has none.
+type polynomial struct {
+	coeffs []*bigInteger
+}
+
+// NewPolynomial returns the polynomial with the given coefficients, the
+// constant term first.
+func NewPolynomial(coeffs ...*bigInteger) *polynomial {
+	return newPolynomial(append([]*bigInteger(nil), coeffs...))
+}
+
+// newPolynomial takes ownership of coeffs and drops the leading zeros.
+func newPolynomial(coeffs []*bigInteger) *polynomial {
+	n := len(coeffs)
+	for n > 0 && coeffs[n-1].signum == 0 {
+		n--
+	}
+	return &polynomial{coeffs: coeffs[:n]}
+}
+
+// Degree returns the degree, -1 for the zero polynomial.
+func (p *polynomial) Degree() types.Int {
+	return types.Int(len(p.coeffs)) - 1
+}
+
+// Coefficient returns the coefficient of x^i.
+func (p *polynomial) Coefficient(i types.Int) *bigInteger {
+	if i < 0 || i >= types.Int(len(p.coeffs)) {
+		return ZERO
+	}
+	return p.coeffs[i]
+}
+
+// Coefficients returns a copy of the coefficients, the constant term first.
+func (p *polynomial) Coefficients() []*bigInteger {
+	return append([]*bigInteger(nil), p.coeffs...)
+}
+
+// LeadingCoefficient returns the coefficient of the highest power, zero for
+// the zero polynomial.
+func (p *polynomial) LeadingCoefficient() *bigInteger {
+	return p.Coefficient(p.Degree())
+}
+
+// Equals reports whether p and q have the same coefficients.
+func (p *polynomial) Equals(q *polynomial) bool {
+	if len(p.coeffs) != len(q.coeffs) {
+		return false
+	}
+	for i, c := range p.coeffs {
+		if c.CompareTo(q.coeffs[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the polynomial in x, highest power first, e.g. 3x^2 - x + 5.
+func (p *polynomial) String() string {
+	if len(p.coeffs) == 0 {
+		return "0"
+	}
+	var buf bytes.Buffer
+	for i := len(p.coeffs) - 1; i >= 0; i-- {
+		c := p.coeffs[i]
+		if c.signum == 0 {
+			continue
+		}
+		switch {
+		case buf.Len() == 0 && c.signum < 0:
+			buf.WriteString("-")
+		case buf.Len() > 0 && c.signum < 0:
+			buf.WriteString(" - ")
+		case buf.Len() > 0:
+			buf.WriteString(" + ")
+		}
+		if i == 0 || c.compareMagnituteLong(1) != 0 {
+			buf.WriteString(c.Abs().String())
+		}
+		if i > 0 {
+			buf.WriteString("x")
+		}
+		if i > 1 {
+			buf.WriteString("^")
+			buf.WriteString(BigIntegerValueOf(types.Long(i)).String())
+		}
+	}
+	return buf.String()
+}
+
+// Add returns p + q.
+func (p *polynomial) Add(q *polynomial) *polynomial {
+	n := tool.MaxInt(types.Int(len(p.coeffs)), types.Int(len(q.coeffs)))
+	r := make([]*bigInteger, n)
+	for i := range r {
+		r[i] = p.Coefficient(types.Int(i)).Add(q.Coefficient(types.Int(i)))
+	}
+	return newPolynomial(r)
+}
+
+// Subtract returns p - q.
+func (p *polynomial) Subtract(q *polynomial) *polynomial {
+	return p.Add(q.Negate())
+}
+
+// Negate returns -p.
+func (p *polynomial) Negate() *polynomial {
+	return p.scale(NEGATIVE_ONE)
+}
+
+// scale returns c p.
+func (p *polynomial) scale(c *bigInteger) *polynomial {
+	r := make([]*bigInteger, len(p.coeffs))
+	for i, a := range p.coeffs {
+		r[i] = a.Multiply(c)
+	}
+	return newPolynomial(r)
+}
+
+// Multiply returns p q. The coefficients are packed into one bigInteger each,
+// as the values at x = 2^k for a k that leaves room for every product
+// coefficient, so the work is a single big multiplication (Kronecker
+// substitution).
+func (p *polynomial) Multiply(q *polynomial) *polynomial {
+	if len(p.coeffs) == 0 || len(q.coeffs) == 0 {
+		return newPolynomial(nil)
+	}
+	if len(p.coeffs) == 1 {
+		return q.scale(p.coeffs[0])
+	}
+	if len(q.coeffs) == 1 {
+		return p.scale(q.coeffs[0])
+	}
+	// a product coefficient is a sum of at most min(len) products, plus a sign bit
+	terms := tool.MinInt(types.Int(len(p.coeffs)), types.Int(len(q.coeffs)))
+	bits := p.maxBitLength() + q.maxBitLength() + bitLengthForInt(terms) + 1
+	words := (bits + 31) / 32
+	product := kroneckerPack(p.coeffs, words).Multiply(kroneckerPack(q.coeffs, words))
+	return newPolynomial(kroneckerUnpack(product, words, types.Int(len(p.coeffs)+len(q.coeffs)-1)))
+}
+
+func (p *polynomial) maxBitLength() types.Int {
+	m := types.Int(0)
+	for _, c := range p.coeffs {
+		m = tool.MaxInt(m, c.Abs().BitLength())
+	}
+	return m
+}
+
+// kroneckerPack returns the sum of coeffs[i] 2^(32 words i).
+func kroneckerPack(coeffs []*bigInteger, words types.Int) *bigInteger {
+	n := types.Int(len(coeffs))
+	pos, neg := make([]types.Int, n*words), make([]types.Int, n*words)
+	for i, c := range coeffs {
+		dst := pos
+		if c.signum < 0 {
+			dst = neg
+		}
+		end := (n - types.Int(i)) * words
+		copy(dst[end-types.Int(len(c.mag)):end], c.mag)
+	}
+	return newBigInteger(trustedStripLeadingZeroInts(pos), 1).Subtract(newBigInteger(trustedStripLeadingZeroInts(neg), 1))
+}
+
+// kroneckerUnpack splits v into n balanced digits of 32 words bits, each in
+// [-2^(32 words - 1), 2^(32 words - 1)).
+func kroneckerUnpack(v *bigInteger, words, n types.Int) []*bigInteger {
+	digits := make([]types.Int, n*words)
+	copy(digits[n*words-types.Int(len(v.mag)):], v.mag)
+	radix := ONE.shiftLeft(32 * words)
+	coeffs := make([]*bigInteger, n)
+	carry := types.Long(0)
+	for j := types.Int(0); j < n; j++ {
+		slot := digits[(n-1-j)*words : (n-j)*words]
+		d := newBigInteger(trustedStripLeadingZeroInts(slot), 1).add(carry)
+		carry = 0
+		if d.BitLength() >= 32*words {
+			// the upper half of the slot is a negative digit and a carry
+			d, carry = d.Subtract(radix), 1
+		}
+		if v.signum < 0 {
+			d = d.negate()
+		}
+		coeffs[j] = d
+	}
+	return coeffs
+}
+
+// DivideAndRemainder returns an array of two polynomials q and r with
+// p = q d + r and deg r < deg d. It panics when d is zero or a quotient
+// coefficient is not an integer, which cannot happen for a monic d.
+func (p *polynomial) DivideAndRemainder(d *polynomial) []*polynomial {
+	q, r, ok := p.divide(d, false)
+	if !ok {
+		panic(errors.New("polynomial quotient is not integral"))
+	}
+	return []*polynomial{q, r}
+}
+
+// PseudoDivideAndRemainder returns an array of two polynomials q and r with
+// lc(d)^(deg p - deg d + 1) p = q d + r and deg r < deg d, or q = 0 and r = p
+// when deg p < deg d. It needs no division of coefficients.
+func (p *polynomial) PseudoDivideAndRemainder(d *polynomial) []*polynomial {
+	q, r, _ := p.divide(d, true)
+	return []*polynomial{q, r}
+}
+
+// divide is the long division by d, ok is false when a quotient coefficient
+// is not an integer.
+func (p *polynomial) divide(d *polynomial, pseudo bool) (q, r *polynomial, ok bool) {
+	m := d.Degree()
+	if m < 0 {
+		panic(errors.New("polynomial divide by zero"))
+	}
+	if p.Degree() < m {
+		return newPolynomial(nil), p, true
+	}
+	lc := d.LeadingCoefficient()
+	rc := append([]*bigInteger(nil), p.coeffs...)
+	qc := make([]*bigInteger, p.Degree()-m+1)
+	for i := range qc {
+		qc[i] = ZERO
+	}
+	for i := types.Int(len(qc)) - 1; i >= 0; i-- {
+		top := rc[i+m]
+		if pseudo {
+			// lc r - top x^i d, the quotient so far is scaled along
+			for j := range qc {
+				qc[j] = qc[j].Multiply(lc)
+			}
+			for j := types.Int(0); j < i+m; j++ {
+				rc[j] = rc[j].Multiply(lc)
+			}
+			qc[i] = top
+		} else {
+			qr := top.DivideAndRemainder(lc)
+			if qr[1].signum != 0 {
+				return nil, nil, false
+			}
+			qc[i], top = qr[0], qr[0]
+		}
+		for j := types.Int(0); j < m; j++ {
+			rc[i+j] = rc[i+j].Subtract(top.Multiply(d.coeffs[j]))
+		}
+		rc[i+m] = ZERO
+	}
+	return newPolynomial(qc), newPolynomial(rc[:m]), true
+}
+
+// divides reports whether d divides p over the integers.
+func (p *polynomial) divides(d *polynomial) bool {
+	_, r, ok := p.divide(d, false)
+	return ok && len(r.coeffs) == 0
+}
+
+// Content returns the greatest common divisor of the coefficients.
+func (p *polynomial) Content() *bigInteger {
+	return GcdMany(p.coeffs...)
+}
+
+// PrimitivePart returns p divided by its content, with a positive leading
+// coefficient.
+func (p *polynomial) PrimitivePart() *polynomial {
+	if len(p.coeffs) == 0 {
+		return p
+	}
+	c := p.Content()
+	if p.LeadingCoefficient().signum < 0 {
+		c = c.negate()
+	}
+	return p.scaleDown(c)
+}
+
+// Gcd returns the greatest common divisor of p and q over the integers, with
+// a positive leading coefficient.
+func (p *polynomial) Gcd(q *polynomial) *polynomial {
+	if len(p.coeffs) == 0 {
+		return q.PrimitivePart().scale(q.Content())
+	}
+	if len(q.coeffs) == 0 {
+		return p.PrimitivePart().scale(p.Content())
+	}
+	c := p.Content().Gcd(q.Content())
+	a, b := p.PrimitivePart(), q.PrimitivePart()
+	if a.Degree() < b.Degree() {
+		a, b = b, a
+	}
+	g, ok := heuristicGcd(a, b)
+	if !ok {
+		g = subresultantGcd(a, b)
+	}
+	return g.scale(c)
+}
+
+// heuristicGcd tries the gcd of the primitive a and b by evaluation: the
+// integer gcd of a(x) and b(x) at a large point x holds the gcd polynomial as
+// its balanced base x digits, which is accepted once it divides a and b
+// (Char, Geddes and Gonnet).
+func heuristicGcd(a, b *polynomial) (*polynomial, bool) {
+	na, nb := a.maxNorm(), b.maxNorm()
+	bound := na
+	if nb.CompareTo(bound) < 0 {
+		bound = nb
+	}
+	x := na.Divide(a.LeadingCoefficient()).Add(nb.Divide(b.LeadingCoefficient()))
+	if y := bound.shiftLeft(1).add(29); y.CompareTo(x) > 0 {
+		x = y
+	}
+	for i := 0; i < 6; i++ {
+		if h := a.Evaluate(x).Gcd(b.Evaluate(x)); h.signum != 0 {
+			g := interpolateBalanced(h, x).PrimitivePart()
+			if g.Degree() >= 0 && a.divides(g) && b.divides(g) {
+				return g, true
+			}
+		}
+		// the next point grows by a factor that avoids the powers of the last
+		x = x.Multiply(x.Sqrt().Sqrt()).multiplyLong(73794).Divide(BigIntegerValueOf(27011))
+	}
+	return nil, false
+}
+
+// interpolateBalanced returns the polynomial whose coefficients are the
+// balanced base x digits of h, each in (-x/2, x/2].
+func interpolateBalanced(h, x *bigInteger) *polynomial {
+	var coeffs []*bigInteger
+	half := x.shiftRight(1)
+	for h.signum != 0 {
+		d := h.Mod(x)
+		if d.CompareTo(half) > 0 {
+			d = d.Subtract(x)
+		}
+		coeffs = append(coeffs, d)
+		h = h.Subtract(d).Divide(x)
+	}
+	return newPolynomial(coeffs)
+}
+
+// subresultantGcd returns the primitive gcd of the primitive a and b with
+// deg a >= deg b by the subresultant remainder sequence, whose exact
+// divisions keep the coefficients from growing exponentially.
+func subresultantGcd(a, b *polynomial) *polynomial {
+	g, h := ONE, ONE
+	for b.Degree() > 0 {
+		delta := a.Degree() - b.Degree()
+		r := a.PseudoDivideAndRemainder(b)[1]
+		if len(r.coeffs) == 0 {
+			return b.PrimitivePart()
+		}
+		// b' = r / (g h^delta), g' = lc(b), h' = g'^delta / h^(delta-1)
+		a, b = b, r.scaleDown(g.Multiply(h.Pow(delta)))
+		g = a.LeadingCoefficient()
+		if delta > 0 {
+			h = g.Pow(delta).Divide(h.Pow(delta - 1))
+		}
+	}
+	return NewPolynomial(ONE)
+}
+
+// maxNorm returns the largest absolute value of the coefficients.
+func (p *polynomial) maxNorm() *bigInteger {
+	m := ZERO
+	for _, c := range p.coeffs {
+		if c.compareMagnitute(m) > 0 {
+			m = c.Abs()
+		}
+	}
+	return m
+}
+
+// Evaluate returns p(x) by Horner's rule.
+func (p *polynomial) Evaluate(x *bigInteger) *bigInteger {
+	r := ZERO
+	for i := len(p.coeffs) - 1; i >= 0; i-- {
+		r = r.Multiply(x).Add(p.coeffs[i])
+	}
+	return r
+}
+
+// Derivative returns p'.
+func (p *polynomial) Derivative() *polynomial {
+	if len(p.coeffs) < 2 {
+		return newPolynomial(nil)
+	}
+	r := make([]*bigInteger, len(p.coeffs)-1)
+	for i := range r {
+		r[i] = p.coeffs[i+1].multiplyLong(types.Long(i + 1))
+	}
+	return newPolynomial(r)
+}
+
+// Mod returns p with every coefficient reduced into [0, m).
+func (p *polynomial) Mod(m *bigInteger) *polynomial {
+	c := NewModContext(m)
+	r := make([]*bigInteger, len(p.coeffs))
+	for i, a := range p.coeffs {
+		r[i] = c.Reduce(a)
+	}
+	return newPolynomial(r)
+}
+
+// DivideAndRemainderMod returns an array of two polynomials q and r with
+// p = q d + r modulo m and deg r < deg d, all coefficients in [0, m). The
+// leading coefficient of d must be invertible modulo m.
+func (p *polynomial) DivideAndRemainderMod(d *polynomial, m *bigInteger) []*polynomial {
+	c := NewModContext(m)
+	d = d.Mod(m)
+	k := d.Degree()
+	if k < 0 {
+		panic(errors.New("polynomial divide by zero"))
+	}
+	r := p.Mod(m).Coefficients()
+	if types.Int(len(r)) <= k {
+		return []*polynomial{newPolynomial(nil), newPolynomial(r)}
+	}
+	inv := c.Inverse(d.LeadingCoefficient())
+	q := make([]*bigInteger, types.Int(len(r))-k)
+	for i := types.Int(len(q)) - 1; i >= 0; i-- {
+		t := c.MulMod(r[i+k], inv)
+		q[i] = t
+		for j := types.Int(0); j < k; j++ {
+			r[i+j] = c.Reduce(r[i+j].Subtract(t.Multiply(d.coeffs[j])))
+		}
+	}
+	return []*polynomial{newPolynomial(q), newPolynomial(r[:k])}
+}
+
+// GcdMod returns the monic greatest common divisor of p and q modulo the
+// prime m.
+func (p *polynomial) GcdMod(q *polynomial, m *bigInteger) *polynomial {
+	a, b := p.Mod(m), q.Mod(m)
+	for len(b.coeffs) > 0 {
+		a, b = b, a.DivideAndRemainderMod(b, m)[1]
+	}
+	if len(a.coeffs) == 0 {
+		return a
+	}
+	c := NewModContext(m)
+	inv := c.Inverse(a.LeadingCoefficient())
+	r := make([]*bigInteger, len(a.coeffs))
+	for i, x := range a.coeffs {
+		r[i] = c.MulMod(x, inv)
+	}
+	return newPolynomial(r)
+}
+
+// IntegerRoots returns the distinct integer roots in ascending order. They
+// are isolated by bisection of the Cauchy bound with Sturm sequences, which
+// count the real roots in an interval, and a unit interval holding a root is
+// checked for an integer one.
+func (p *polynomial) IntegerRoots() []*bigInteger {
+	if len(p.coeffs) == 0 {
+		panic(errors.New("the zero polynomial has every root"))
+	}
+	// the square-free part has the same roots, each once
+	s := p
+	if g := p.Gcd(p.Derivative()); g.Degree() > 0 {
+		s = p.DivideAndRemainder(g)[0]
+	}
+	if s.Degree() < 1 {
+		return nil
+	}
+	sturm := s.sturmSequence()
+	// every root satisfies |x| < 1 + max |a_i / a_n|
+	bound := s.maxNorm().Divide(s.LeadingCoefficient().Abs()).add(2)
+
+	var roots []*bigInteger
+	var search func(lo, hi *bigInteger, vlo, vhi types.Int)
+	search = func(lo, hi *bigInteger, vlo, vhi types.Int) {
+		// vlo - vhi real roots lie in (lo, hi]
+		if vlo == vhi {
+			return
+		}
+		if hi.Subtract(lo).compareMagnituteLong(1) == 0 {
+			if s.Evaluate(hi).signum == 0 {
+				roots = append(roots, hi)
+			}
+			return
+		}
+		mid := lo.Add(hi).shiftRight(1)
+		vmid := signVariations(sturm, mid)
+		search(lo, mid, vlo, vmid)
+		search(mid, hi, vmid, vhi)
+	}
+	lo, hi := bound.negate(), bound
+	search(lo, hi, signVariations(sturm, lo), signVariations(sturm, hi))
+	return roots
+}
+
+// sturmSequence returns p, p' and the negated remainders of the Euclidean
+// algorithm. Pseudo-division by a positive multiple and the removal of
+// positive contents keep the signs the ones of the true remainders.
+func (p *polynomial) sturmSequence() []*polynomial {
+	seq := []*polynomial{p, p.Derivative()}
+	for {
+		a, b := seq[len(seq)-2], seq[len(seq)-1]
+		r := a.PseudoDivideAndRemainder(b)[1]
+		if len(r.coeffs) == 0 {
+			return seq
+		}
+		// the pseudo remainder is lc(b)^(deg a - deg b + 1) times the true one
+		if b.LeadingCoefficient().signum < 0 && (a.Degree()-b.Degree())&1 == 0 {
+			r = r.Negate()
+		}
+		seq = append(seq, r.Negate().scaleDown(r.Content()))
+	}
+}
+
+// scaleDown returns p divided by c, a divisor of every coefficient.
+func (p *polynomial) scaleDown(c *bigInteger) *polynomial {
+	r := make([]*bigInteger, len(p.coeffs))
+	for i, a := range p.coeffs {
+		r[i] = a.Divide(c)
+	}
+	return newPolynomial(r)
+}
+
+// signVariations returns the number of sign changes of the sequence at x,
+// zeros skipped.
+func signVariations(seq []*polynomial, x *bigInteger) types.Int {
+	v, last := types.Int(0), types.Int(0)
+	for _, p := range seq {
+		s := p.Evaluate(x).signum
+		if s != 0 {
+			if last != 0 && s != last {
+				v++
+			}
+			last = s
+		}
+	}
+	return v
+}
@@ -0,0 +1,189 @@
+package bigger
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// ratPolynomial is a dense univariate polynomial with rational coefficients,
+// held as num / den over a common denominator. It is immutable, den is
+// positive and shares no factor with the content of num, so every rational
+// polynomial has exactly one such form.
+type ratPolynomial struct {
+	num *polynomial
+	den *bigInteger
+}
+
+// NewRationalPolynomial returns the polynomial num / den.
+func NewRationalPolynomial(num *polynomial, den *bigInteger) *ratPolynomial {
+	return newRatPolynomial(num, den)
+}
+
+// NewRationalPolynomialString returns the polynomial with the given
+// coefficients, the constant term first, each an integer or a fraction such
+// as -3/4.
+func NewRationalPolynomialString(coeffs ...string) *ratPolynomial {
+	nums, dens := make([]*bigInteger, len(coeffs)), make([]*bigInteger, len(coeffs))
+	for i, c := range coeffs {
+		dens[i] = ONE
+		if k := strings.IndexByte(c, '/'); k >= 0 {
+			c, dens[i] = c[:k], NewBigIntegerString(c[k+1:])
+		}
+		nums[i] = NewBigIntegerString(c)
+	}
+	// clear the denominators with their lcm
+	l := LcmMany(dens...)
+	for i := range nums {
+		nums[i] = nums[i].Multiply(l.Divide(dens[i]))
+	}
+	return newRatPolynomial(newPolynomial(nums), l)
+}
+
+// newRatPolynomial reduces num / den to lowest terms with a positive den.
+func newRatPolynomial(num *polynomial, den *bigInteger) *ratPolynomial {
+	if den.signum == 0 {
+		panic(errors.New("ratPolynomial: zero denominator"))
+	}
+	if len(num.coeffs) == 0 {
+		return &ratPolynomial{num: num, den: ONE}
+	}
+	g := num.Content().Gcd(den)
+	if den.signum < 0 {
+		g = g.negate()
+	}
+	if g.CompareTo(ONE) == 0 {
+		return &ratPolynomial{num: num, den: den}
+	}
+	return &ratPolynomial{num: num.scaleDown(g), den: den.Divide(g)}
+}
+
+// reduceFraction returns n / d in lowest terms with a positive denominator.
+func reduceFraction(n, d *bigInteger) (*bigInteger, *bigInteger) {
+	g := n.Gcd(d)
+	if d.signum < 0 {
+		g = g.negate()
+	}
+	return n.Divide(g), d.Divide(g)
+}
+
+// Numerator returns the integer polynomial num of num / den.
+func (p *ratPolynomial) Numerator() *polynomial {
+	return p.num
+}
+
+// Denominator returns the positive common denominator den of num / den.
+func (p *ratPolynomial) Denominator() *bigInteger {
+	return p.den
+}
+
+// Degree returns the degree, -1 for the zero polynomial.
+func (p *ratPolynomial) Degree() types.Int {
+	return p.num.Degree()
+}
+
+// Coefficient returns the coefficient of x^i as a numerator and a positive
+// denominator in lowest terms.
+func (p *ratPolynomial) Coefficient(i types.Int) (*bigInteger, *bigInteger) {
+	return reduceFraction(p.num.Coefficient(i), p.den)
+}
+
+// Equals reports whether p and q have the same coefficients.
+func (p *ratPolynomial) Equals(q *ratPolynomial) bool {
+	return p.den.CompareTo(q.den) == 0 && p.num.Equals(q.num)
+}
+
+// String returns the numerator polynomial in x over the denominator, e.g.
+// (3x^2 + 1)/2, or the numerator alone when the denominator is one. A
+// constant reads as a fraction, e.g. -5/4.
+func (p *ratPolynomial) String() string {
+	if p.den.compareMagnituteLong(1) == 0 {
+		return p.num.String()
+	}
+	if p.num.Degree() == 0 {
+		return p.num.String() + "/" + p.den.String()
+	}
+	return "(" + p.num.String() + ")/" + p.den.String()
+}
+
+// Add returns p + q.
+func (p *ratPolynomial) Add(q *ratPolynomial) *ratPolynomial {
+	return newRatPolynomial(p.num.scale(q.den).Add(q.num.scale(p.den)), p.den.Multiply(q.den))
+}
+
+// Subtract returns p - q.
+func (p *ratPolynomial) Subtract(q *ratPolynomial) *ratPolynomial {
+	return p.Add(q.Negate())
+}
+
+// Negate returns -p.
+func (p *ratPolynomial) Negate() *ratPolynomial {
+	return &ratPolynomial{num: p.num.Negate(), den: p.den}
+}
+
+// Multiply returns p q.
+func (p *ratPolynomial) Multiply(q *ratPolynomial) *ratPolynomial {
+	return newRatPolynomial(p.num.Multiply(q.num), p.den.Multiply(q.den))
+}
+
+// DivideAndRemainder returns an array of two polynomials q and r with
+// p = q d + r and deg r < deg d. Over the rationals every nonzero d divides,
+// it panics when d is zero.
+func (p *ratPolynomial) DivideAndRemainder(d *ratPolynomial) []*ratPolynomial {
+	// with k = deg p - deg d + 1, the pseudo division
+	// lc^k p.num = Q d.num + R leaves
+	// p = (Q d.den / (lc^k p.den)) d + R / (lc^k p.den)
+	qr := p.num.PseudoDivideAndRemainder(d.num)
+	if p.Degree() < d.Degree() {
+		return []*ratPolynomial{qr[0].ratPolynomial(), p}
+	}
+	scale := d.num.LeadingCoefficient().Pow(p.Degree() - d.Degree() + 1).Multiply(p.den)
+	return []*ratPolynomial{newRatPolynomial(qr[0].scale(d.den), scale), newRatPolynomial(qr[1], scale)}
+}
+
+// Gcd returns the monic greatest common divisor of p and q over the
+// rationals, zero when both are zero.
+func (p *ratPolynomial) Gcd(q *ratPolynomial) *ratPolynomial {
+	g := p.num.Gcd(q.num)
+	if len(g.coeffs) == 0 {
+		return g.ratPolynomial()
+	}
+	return newRatPolynomial(g, g.LeadingCoefficient())
+}
+
+// Evaluate returns p(x) at x = xn / xd by Horner's rule on the homogenised
+// numerator, as a numerator and a positive denominator in lowest terms.
+func (p *ratPolynomial) Evaluate(xn, xd *bigInteger) (*bigInteger, *bigInteger) {
+	if xd.signum == 0 {
+		panic(errors.New("ratPolynomial: zero denominator"))
+	}
+	n := len(p.num.coeffs)
+	if n == 0 {
+		return ZERO, ONE
+	}
+	// r = sum c_i xn^i xd^(n-1-i)
+	r, pw := p.num.coeffs[n-1], ONE
+	for i := n - 2; i >= 0; i-- {
+		pw = pw.Multiply(xd)
+		r = r.Multiply(xn).Add(p.num.coeffs[i].Multiply(pw))
+	}
+	return reduceFraction(r, p.den.Multiply(pw))
+}
+
+// Derivative returns p'.
+func (p *ratPolynomial) Derivative() *ratPolynomial {
+	return newRatPolynomial(p.num.Derivative(), p.den)
+}
+
+// Mod returns p with every coefficient reduced into [0, m), the denominator
+// taken as its inverse modulo m. It panics when the denominator is not
+// invertible modulo m.
+func (p *ratPolynomial) Mod(m *bigInteger) *polynomial {
+	return p.num.scale(p.den.ModInverse(m)).Mod(m)
+}
+
+// ratPolynomial returns p over the denominator one.
+func (p *polynomial) ratPolynomial() *ratPolynomial {
+	return &ratPolynomial{num: p, den: ONE}
+}
@@ -0,0 +1,274 @@
+package bigger
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+const (
+	// overline is the combining character drawn over a repeated digit.
+	overline = '̅'
+	// p_REPETEND_MAX_DIGITS bounds the repetend of RepeatingDecimal, a period
+	// of that many digits is a megabyte of string and as many division steps
+	p_REPETEND_MAX_DIGITS = 1 << 20
+)
+
+// repeatingDecimal is the exact decimal expansion of a fraction: an integer
+// part, the digits before the period and the repeated digits, the repetend.
+// The repetend is empty when the expansion terminates.
+type repeatingDecimal struct {
+	signum       types.Int
+	integer      *bigInteger // the magnitude of the integer part
+	nonRepeating string
+	repetend     string
+}
+
+// RepeatingDecimal returns the decimal expansion of r. Both parts are as
+// short as possible, 1/6 = 0.1(6). The repetend of a denominator 2^a 5^b m,
+// m coprime to 10, has as many digits as the order of 10 modulo m, up to
+// m - 1, and each costs a step of long division: a denominator of n bits
+// may take 2^n steps. It panics when the repetend is longer than 2^20
+// digits, RepeatingDecimalLimit takes another bound.
+func (r *bigRational) RepeatingDecimal() *repeatingDecimal {
+	d, ok := r.RepeatingDecimalLimit(p_REPETEND_MAX_DIGITS)
+	if !ok {
+		panic(errors.New("BigRational: repetend too long"))
+	}
+	return d
+}
+
+// RepeatingDecimalLimit returns the decimal expansion of r as
+// RepeatingDecimal does and true, or nil and false as soon as the repetend
+// grows past maxPeriod digits. The long division takes at most maxPeriod
+// steps.
+func (r *bigRational) RepeatingDecimalLimit(maxPeriod types.Int) (*repeatingDecimal, bool) {
+	num, den := r.num.Abs(), r.den
+	qr := num.DivideAndRemainder(den)
+	d := &repeatingDecimal{signum: r.num.signum, integer: qr[0]}
+	if qr[1].signum == 0 {
+		return d, true
+	}
+
+	// the period starts after k digits and is the order of 10 modulo m
+	m, k := splitTwosAndFives(den)
+	rem := qr[1]
+	if k > 0 {
+		p := rem.Multiply(TEN.Pow(k)).DivideAndRemainder(den)
+		digits := p[0].String()
+		d.nonRepeating = strings.Repeat("0", int(k)-len(digits)) + digits
+		rem = p[1]
+	}
+	if rem.signum != 0 {
+		// the order of 10 modulo m is less than m
+		if m.compareMagnituteLong(maxPeriod.ToLong()) <= 0 {
+			maxPeriod = types.Int(m.LongValue()) - 1
+		}
+		rep, ok := repetend(rem, den, maxPeriod)
+		if !ok {
+			return nil, false
+		}
+		d.repetend = rep
+	}
+	return d, true
+}
+
+// splitTwosAndFives writes den > 0 as 2^a 5^b m with m coprime to 10 and
+// returns m and max(a, b), the digits of 1/(2^a 5^b).
+func splitTwosAndFives(den *bigInteger) (*bigInteger, types.Int) {
+	k := den.getLowestSetBit()
+	m := den.shiftRight(k)
+	var fives types.Int
+	five := BigIntegerValueOf(5)
+	for ; ; fives++ {
+		d5 := m.DivideAndRemainder(five)
+		if d5[1].signum != 0 {
+			break
+		}
+		m = d5[0]
+	}
+	if fives > k {
+		k = fives
+	}
+	return m, k
+}
+
+// repetend returns the digits of rem/den by long division until the
+// remainder comes back to rem, for a den coprime to 10 in what remains of it,
+// and false when that takes more than maxPeriod digits.
+func repetend(rem, den *bigInteger, maxPeriod types.Int) (string, bool) {
+	var sb strings.Builder
+	// ten times a remainder fits in 64 bits
+	if den.BitLength() <= 59 {
+		r0, n := uint64(rem.LongValue()), uint64(den.LongValue())
+		for r := r0; sb.Len() < int(maxPeriod); {
+			r *= 10
+			sb.WriteByte(byte('0' + r/n))
+			if r %= n; r == r0 {
+				return sb.String(), true
+			}
+		}
+		return "", false
+	}
+	for r := rem; sb.Len() < int(maxPeriod); {
+		qr := r.Multiply(TEN).DivideAndRemainder(den)
+		sb.WriteByte(byte('0' + qr[0].LongValue()))
+		if r = qr[1]; r.CompareTo(rem) == 0 {
+			return sb.String(), true
+		}
+	}
+	return "", false
+}
+
+// DivideRepeating returns the exact expansion of b / val at the cost of
+// RepeatingDecimal. It panics when val is zero or the repetend is longer
+// than 2^20 digits.
+func (b *bigInteger) DivideRepeating(val *bigInteger) *repeatingDecimal {
+	return NewBigRational(b, val).RepeatingDecimal()
+}
+
+// DivideRepeating returns the exact expansion of b / divisor, where Divide
+// would need a rounding, at the cost of RepeatingDecimal. It panics when
+// divisor is zero or the repetend is longer than 2^20 digits.
+func (b *bigDecimal) DivideRepeating(divisor *bigDecimal) *repeatingDecimal {
+	return NewBigRationalBigDecimal(b).Divide(NewBigRationalBigDecimal(divisor)).RepeatingDecimal()
+}
+
+// Signum returns -1, 0 or 1 as the value is negative, zero or positive.
+func (d *repeatingDecimal) Signum() types.Int {
+	return d.signum
+}
+
+// IntegerPart returns the integer part, truncated toward zero.
+func (d *repeatingDecimal) IntegerPart() *bigInteger {
+	if d.signum < 0 {
+		return d.integer.negate()
+	}
+	return d.integer
+}
+
+// NonRepeating returns the fraction digits before the period.
+func (d *repeatingDecimal) NonRepeating() string {
+	return d.nonRepeating
+}
+
+// Repetend returns the repeated digits, empty for a terminating expansion.
+func (d *repeatingDecimal) Repetend() string {
+	return d.repetend
+}
+
+// IsTerminating reports whether the expansion ends.
+func (d *repeatingDecimal) IsTerminating() bool {
+	return d.repetend == ""
+}
+
+// BigRational returns the exact value.
+func (d *repeatingDecimal) BigRational() *bigRational {
+	return repeatingValue(d.signum < 0, d.integer.String(), d.nonRepeating, d.repetend)
+}
+
+// String returns the expansion with the repetend in parentheses, -0.1(6).
+func (d *repeatingDecimal) String() string {
+	if d.repetend == "" {
+		return d.format(d.nonRepeating)
+	}
+	return d.format(d.nonRepeating + "(" + d.repetend + ")")
+}
+
+// OverlineString returns the expansion with a combining overline on every
+// digit of the repetend, -0.16̅.
+func (d *repeatingDecimal) OverlineString() string {
+	var sb strings.Builder
+	sb.WriteString(d.nonRepeating)
+	for _, c := range d.repetend {
+		sb.WriteRune(c)
+		sb.WriteRune(overline)
+	}
+	return d.format(sb.String())
+}
+
+func (d *repeatingDecimal) format(fraction string) string {
+	s := d.integer.String()
+	if fraction != "" {
+		s += "." + fraction
+	}
+	if d.signum < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// NewBigRationalRepeating parses a decimal with a repetend in parentheses,
+// "-1.2(36)", or overlined, "-1.23̅6̅", into its exact value. A plain decimal
+// without a repetend is accepted as well.
+func NewBigRationalRepeating(val string) *bigRational {
+	val = strings.TrimSpace(val)
+	negative := false
+	if val != "" && (val[0] == '-' || val[0] == '+') {
+		negative, val = val[0] == '-', val[1:]
+	}
+	integer, fraction := val, ""
+	if point := strings.IndexByte(val, '.'); point >= 0 {
+		integer, fraction = val[:point], val[point+1:]
+	}
+	nonRepeating, rep := fraction, ""
+	if open := strings.IndexByte(fraction, '('); open >= 0 {
+		if !strings.HasSuffix(fraction, ")") || open+2 > len(fraction)-1 {
+			panic(errors.New("BigRational: bad repetend"))
+		}
+		nonRepeating, rep = fraction[:open], fraction[open+1:len(fraction)-1]
+	} else if strings.ContainsRune(fraction, overline) {
+		nonRepeating, rep = splitOverline(fraction)
+	}
+	if integer == "" && fraction == "" || !isDigits(integer) || !isDigits(nonRepeating) || !isDigits(rep) {
+		panic(errors.New("BigRational: bad repeating decimal"))
+	}
+	if integer == "" {
+		integer = "0"
+	}
+	return repeatingValue(negative, integer, nonRepeating, rep)
+}
+
+// splitOverline splits fraction digits into the plain ones and the overlined
+// ones, which must come last.
+func splitOverline(fraction string) (string, string) {
+	var plain, rep strings.Builder
+	runes := []rune(fraction)
+	for i := 0; i < len(runes); i++ {
+		if i+1 < len(runes) && runes[i+1] == overline {
+			rep.WriteRune(runes[i])
+			i++
+		} else if rep.Len() > 0 || runes[i] == overline {
+			panic(errors.New("BigRational: bad repetend"))
+		} else {
+			plain.WriteRune(runes[i])
+		}
+	}
+	return plain.String(), rep.String()
+}
+
+// repeatingValue returns integer.nonRepeating(rep) as
+// (integer nonRepeating rep - integer nonRepeating) / (10^k (10^n - 1)).
+func repeatingValue(negative bool, integer, nonRepeating, rep string) *bigRational {
+	whole := NewBigIntegerString(integer + nonRepeating)
+	den := TEN.Pow(types.Int(len(nonRepeating)))
+	num := whole
+	if rep != "" {
+		num = NewBigIntegerString(integer + nonRepeating + rep).Subtract(whole)
+		den = den.Multiply(TEN.Pow(types.Int(len(rep))).Subtract(ONE))
+	}
+	if negative {
+		num = num.negate()
+	}
+	return NewBigRational(num, den)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
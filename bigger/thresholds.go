@@ -0,0 +1,206 @@
+package bigger
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Thresholds is a set of algorithm crossover points. All values are operand
+// lengths in 32-bit ints. The fields are exported so that a calibrated set
+// can be persisted, e.g. with encoding/json, and loaded with SetThresholds.
+type Thresholds struct {
+	KaratsubaSquare          types.Int `json:"karatsubaSquare"`          // schoolbook -> Karatsuba, multiply and square
+	ToomCookSquare           types.Int `json:"toomCookSquare"`           // Karatsuba -> Toom-Cook 3, multiply and square
+	FFTMultiply              types.Int `json:"fftMultiply"`              // Toom-Cook 3 -> NTT multiply
+	FFTSquare                types.Int `json:"fftSquare"`                // Toom-Cook 3 -> NTT square
+	BurnikelZiegler          types.Int `json:"burnikelZiegler"`          // Knuth -> Burnikel-Ziegler divisor length
+	BurnikelZieglerOffset    types.Int `json:"burnikelZieglerOffset"`    // minimal dividend excess for Burnikel-Ziegler
+	SchoenhageBaseConversion types.Int `json:"schoenhageBaseConversion"` // iterative -> recursive toString
+	Parallel                 types.Int `json:"parallel"`                 // minimal length handed to other goroutines
+}
+
+// DefaultThresholds returns the built-in thresholds, the JDK values for the
+// classic algorithms.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		KaratsubaSquare:          128,
+		ToomCookSquare:           216,
+		FFTMultiply:              1792,
+		FFTSquare:                1792,
+		BurnikelZiegler:          80,
+		BurnikelZieglerOffset:    40,
+		SchoenhageBaseConversion: 20,
+		Parallel:                 512,
+	}
+}
+
+// GetThresholds returns the thresholds currently in use.
+func GetThresholds() Thresholds {
+	return Thresholds{
+		KaratsubaSquare:          p_KARATSUBA_SQUARE_THRESHOLD,
+		ToomCookSquare:           p_TOOM_COOK_SQUARE_THRESHOLD,
+		FFTMultiply:              p_FFT_MULTIPLY_THRESHOLD,
+		FFTSquare:                p_FFT_SQUARE_THRESHOLD,
+		BurnikelZiegler:          p_BURNIKEL_ZIEGLER_THRESHOLD,
+		BurnikelZieglerOffset:    p_BURNIKEL_ZIEGLER_OFFSET,
+		SchoenhageBaseConversion: p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD,
+		Parallel:                 p_PARALLEL_THRESHOLD,
+	}
+}
+
+// SetThresholds replaces the thresholds in use. It panics when a value would
+// make an algorithm recurse on operands it cannot split. It is not safe to
+// call concurrently with arithmetic.
+func SetThresholds(t Thresholds) {
+	if t.KaratsubaSquare < 2 || t.ToomCookSquare < 3 || t.ToomCookSquare < t.KaratsubaSquare {
+		panic(errors.New("invalid Karatsuba or Toom-Cook threshold"))
+	}
+	if t.FFTMultiply < 1 || t.FFTSquare < 1 {
+		panic(errors.New("invalid fft threshold"))
+	}
+	if t.BurnikelZiegler < 2 || t.BurnikelZieglerOffset < 0 {
+		panic(errors.New("invalid Burnikel-Ziegler threshold"))
+	}
+	if t.SchoenhageBaseConversion < 2 || t.Parallel < 1 {
+		panic(errors.New("invalid base conversion or parallel threshold"))
+	}
+	p_KARATSUBA_SQUARE_THRESHOLD = t.KaratsubaSquare
+	p_TOOM_COOK_SQUARE_THRESHOLD = t.ToomCookSquare
+	p_FFT_MULTIPLY_THRESHOLD = t.FFTMultiply
+	p_FFT_SQUARE_THRESHOLD = t.FFTSquare
+	p_BURNIKEL_ZIEGLER_THRESHOLD = t.BurnikelZiegler
+	p_BURNIKEL_ZIEGLER_OFFSET = t.BurnikelZieglerOffset
+	p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD = t.SchoenhageBaseConversion
+	p_PARALLEL_THRESHOLD = t.Parallel
+}
+
+// Calibrate times the competing algorithms on this machine and returns the
+// crossover points it found. The thresholds in use are left unchanged, pass
+// the result to SetThresholds to apply it. Calibration takes a few seconds,
+// it runs sequentially and must not run concurrently with other arithmetic.
+// The Burnikel-Ziegler offset and the parallel threshold are not measured
+// and are copied from the current set.
+func Calibrate() Thresholds {
+	saved, savedSlots := GetThresholds(), parallelSlots
+	defer func() {
+		SetThresholds(saved)
+		parallelSlots = savedSlots
+	}()
+	parallelSlots = nil
+
+	r := rand.New(rand.NewSource(1))
+	t := saved
+	const never = MAX_INT32
+
+	// every measurement runs with the lower crossovers found so far and the
+	// higher algorithms switched off
+	t.KaratsubaSquare, t.ToomCookSquare, t.FFTMultiply, t.FFTSquare = never, never, never, never
+	SetThresholds(t)
+	t.KaratsubaSquare = crossover(16, 512, r, func(x, y *bigInteger) func() {
+		return func() { multiplyToLen(x.mag, types.Int(len(x.mag)), y.mag, types.Int(len(y.mag)), nil) }
+	}, func(x, y *bigInteger) func() {
+		return func() { multiplyKaratsuba(x, y) }
+	})
+	p_KARATSUBA_SQUARE_THRESHOLD = t.KaratsubaSquare
+
+	t.ToomCookSquare = crossover(t.KaratsubaSquare, 2048, r, func(x, y *bigInteger) func() {
+		return func() { multiplyKaratsuba(x, y) }
+	}, func(x, y *bigInteger) func() {
+		return func() { multiplyToomCook3(x, y) }
+	})
+	if t.ToomCookSquare < 3 {
+		t.ToomCookSquare = 3
+	}
+	p_TOOM_COOK_SQUARE_THRESHOLD = t.ToomCookSquare
+
+	t.FFTMultiply = crossover(t.ToomCookSquare, 8192, r, func(x, y *bigInteger) func() {
+		return func() { multiplyToomCook3(x, y) }
+	}, func(x, y *bigInteger) func() {
+		return func() { multiplyNTT(x, y) }
+	})
+	t.FFTSquare = crossover(t.ToomCookSquare, 8192, r, func(x, _ *bigInteger) func() {
+		return func() { x.squareToomCook3() }
+	}, func(x, _ *bigInteger) func() {
+		return func() { x.squareNTT() }
+	})
+	p_FFT_MULTIPLY_THRESHOLD = t.FFTMultiply
+	p_FFT_SQUARE_THRESHOLD = t.FFTSquare
+
+	// divisors of n ints, dividends of 2n ints
+	t.BurnikelZiegler = crossover(16, 1024, r, func(x, y *bigInteger) func() {
+		z := x.Multiply(y).Add(x)
+		return func() { z.divideAndRemainderKnuth(y, nil) }
+	}, func(x, y *bigInteger) func() {
+		z := x.Multiply(y).Add(x)
+		return func() { z.divideAndRemainderBurnikelZiegler(y, nil) }
+	})
+	p_BURNIKEL_ZIEGLER_THRESHOLD = t.BurnikelZiegler
+
+	// the recursive conversion splits once, the halves are converted iteratively
+	t.SchoenhageBaseConversion = crossover(4, 512, r, func(x, _ *bigInteger) func() {
+		return func() { x.smallToString(10, nil) }
+	}, func(x, _ *bigInteger) func() {
+		n := types.Int(len(x.mag))
+		return func() {
+			p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD = n - 1
+			_ = x.String()
+			p_SCHOENHAGE_BASE_CONVERSION_THRESHOLD = n
+		}
+	})
+	return t
+}
+
+// crossover returns the smallest length in [from, to] from which fast beats
+// slow at two thirds of the measured sizes, or to when there is none. A single
+// comparison is not enough, the NTT cost grows in steps of powers of two. The
+// constructors prepare the timed closure for random operands of the given length.
+func crossover(from, to types.Int, r *rand.Rand, slow, fast func(x, y *bigInteger) func()) types.Int {
+	var sizes []types.Int
+	var wins []bool
+	for n := from; n <= to; n += n/8 + 1 {
+		x, y := randomMagnitude(r, n), randomMagnitude(r, n)
+		sizes = append(sizes, n)
+		wins = append(wins, timeOf(fast(x, y)) < timeOf(slow(x, y)))
+	}
+	for i := range sizes {
+		count := 0
+		for _, w := range wins[i:] {
+			if w {
+				count++
+			}
+		}
+		if wins[i] && 3*count >= 2*len(wins[i:]) {
+			return sizes[i]
+		}
+	}
+	return to
+}
+
+// timeOf returns the best of three averaged runs of f.
+func timeOf(f func()) time.Duration {
+	best := time.Duration(1<<63 - 1)
+	for k := 0; k < 3; k++ {
+		reps, start := 0, time.Now()
+		for reps == 0 || time.Since(start) < time.Millisecond {
+			f()
+			reps++
+		}
+		if d := time.Since(start) / time.Duration(reps); d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+// randomMagnitude returns a positive bigInteger of exactly n ints.
+func randomMagnitude(r *rand.Rand, n types.Int) *bigInteger {
+	mag := make([]types.Int, n)
+	for i := range mag {
+		mag[i] = types.Int(r.Uint32())
+	}
+	mag[0] |= 1
+	return newBigInteger(mag, 1)
+}
@@ -0,0 +1,158 @@
+package bigger
+
+import (
+	"errors"
+	"math/bits"
+	"sync"
+
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// Scratch buffers for division, square root and radix conversion.
+//
+// Buffers are kept in size classes of powers of two. A nil *workspace draws
+// them from package level sync.Pools, a workspace created by NewWorkspace
+// keeps its own free lists, so that a loop using it reuses the same buffers
+// on every iteration. Only buffers that no result can reference are handed
+// back: the shifted divisor and the remainder of a quotient-only division,
+// the remainder array of a division by a long, the dividend copies of the
+// iterative radix conversion, and the blocks and partial remainders of
+// Burnikel-Ziegler division. A workspace also keeps the released
+// mutableBigIntegers themselves, so that their structs are reused as well.
+
+const (
+	// p_SCRATCH_MAX_CLASS is the largest size class kept, 2^20 ints
+	p_SCRATCH_MAX_CLASS = 20
+	// p_SCRATCH_PER_CLASS is the number of free buffers a workspace keeps per size class
+	p_SCRATCH_PER_CLASS = 4
+	// p_SCRATCH_MUTABLES is the number of released mutableBigIntegers a workspace keeps
+	p_SCRATCH_MUTABLES = 32
+)
+
+var (
+	scratchPools [p_SCRATCH_MAX_CLASS + 1]sync.Pool
+	// scratchBoxes recycles the empty boxes, boxing a slice for sync.Pool would allocate
+	scratchBoxes = sync.Pool{New: func() interface{} { return new(scratchBox) }}
+)
+
+type scratchBox struct {
+	buf []types.Int
+}
+
+type workspace struct {
+	free     [p_SCRATCH_MAX_CLASS + 1][][]types.Int
+	mutables []*mutableBigInteger
+}
+
+// NewWorkspace returns a workspace for hot loops of divisions, square roots and
+// string conversions. A workspace must not be used by several goroutines at
+// once, parallel subproblems fall back to the shared pools.
+func NewWorkspace() *workspace {
+	return &workspace{}
+}
+
+// getInts returns a zeroed buffer of length n.
+func (w *workspace) getInts(n types.Int) []types.Int {
+	if n <= 0 {
+		return []types.Int{}
+	}
+	class := bits.Len32(uint32(n - 1))
+	if class > p_SCRATCH_MAX_CLASS {
+		return make([]types.Int, n)
+	}
+	var buf []types.Int
+	if w != nil {
+		if free := w.free[class]; len(free) > 0 {
+			buf = free[len(free)-1]
+			w.free[class] = free[:len(free)-1]
+		}
+	} else if v := scratchPools[class].Get(); v != nil {
+		box := v.(*scratchBox)
+		buf, box.buf = box.buf, nil
+		scratchBoxes.Put(box)
+	}
+	if buf == nil {
+		return make([]types.Int, n, 1<<class)
+	}
+	buf = buf[:n]
+	for i := range buf {
+		buf[i] = 0
+	}
+	return buf
+}
+
+// putInts hands a buffer back, it must not be referenced anymore.
+func (w *workspace) putInts(buf []types.Int) {
+	if cap(buf) == 0 {
+		return
+	}
+	class := bits.Len32(uint32(cap(buf))) - 1 // every class member holds at least 2^class ints
+	if class > p_SCRATCH_MAX_CLASS {
+		return
+	}
+	buf = buf[:0]
+	if w != nil {
+		if len(w.free[class]) < p_SCRATCH_PER_CLASS {
+			w.free[class] = append(w.free[class], buf)
+		}
+		return
+	}
+	box := scratchBoxes.Get().(*scratchBox)
+	box.buf = buf
+	scratchPools[class].Put(box)
+}
+
+// newMutable returns a mutableBigInteger holding val that draws its scratch
+// buffers from w, reusing a released one when w keeps any.
+func (w *workspace) newMutable(val []types.Int) *mutableBigInteger {
+	if w == nil || len(w.mutables) == 0 {
+		m := newMutableBigIntegerArray(val)
+		m.ws = w
+		return m
+	}
+	m := w.mutables[len(w.mutables)-1]
+	w.mutables = w.mutables[:len(w.mutables)-1]
+	*m = mutableBigInteger{value: val, intLen: types.Int(len(val)), ws: w}
+	return m
+}
+
+// putMutable keeps a released mutableBigInteger for newMutable.
+func (w *workspace) putMutable(m *mutableBigInteger) {
+	if w != nil && len(w.mutables) < p_SCRATCH_MUTABLES {
+		w.mutables = append(w.mutables, m)
+	}
+}
+
+// mutable returns a mutableBigInteger that shares the magnitude of b and
+// draws its scratch buffers from w. It is only read, never released.
+func (w *workspace) mutable(b *bigInteger) *mutableBigInteger {
+	return w.newMutable(b.mag)
+}
+
+// DivideAndRemainder returns a / b and a % b like a.DivideAndRemainder(b).
+func (w *workspace) DivideAndRemainder(a, b *bigInteger) []*bigInteger {
+	return a.divideAndRemainder(b, w)
+}
+
+// Divide returns a / b like a.Divide(b).
+func (w *workspace) Divide(a, b *bigInteger) *bigInteger {
+	return a.divide(b, w)
+}
+
+// Sqrt returns the integer square root of a like a.Sqrt().
+func (w *workspace) Sqrt(a *bigInteger) *bigInteger {
+	if a.signum < 0 {
+		panic(errors.New("negative BigIntager"))
+	}
+	return a.sqrt(w)
+}
+
+// String returns the decimal representation of a like a.String().
+func (w *workspace) String(a *bigInteger) string {
+	return a.stringRadix(10, w)
+}
+
+// StringRadix returns a in the given radix like a.StringRadix(radix).
+func (w *workspace) StringRadix(a *bigInteger, radix types.Int) string {
+	return a.stringRadix(radix, w)
+}
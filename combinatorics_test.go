@@ -0,0 +1,102 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing combinatorial functions, bigger.bigInteger vs bigInt
+func TestCombinatorics(t *testing.T) {
+	for _, n := range []int64{0, 1, 2, 3, 10, 57, 200, 1001, 5000} {
+		if got := bigger.Factorial(types.Int(n)).String(); got != new(big.Int).MulRange(1, n).String() {
+			t.Errorf("factorial %d mismatch", n)
+		}
+		want := big.NewInt(1)
+		for i := n; i > 1; i -= 2 {
+			want.Mul(want, big.NewInt(i))
+		}
+		if got := bigger.DoubleFactorial(types.Int(n)).String(); got != want.String() {
+			t.Errorf("double factorial %d mismatch", n)
+		}
+		for _, k := range []int64{0, 1, 2, n / 3, n / 2, n - 1, n} {
+			if got := bigger.Binomial(types.Int(n), types.Int(k)).String(); got != new(big.Int).Binomial(n, k).String() {
+				t.Errorf("binomial %d %d mismatch", n, k)
+			}
+		}
+	}
+	want := big.NewInt(1)
+	for p := int64(2); p <= 1000; p++ {
+		if big.NewInt(p).ProbablyPrime(10) {
+			want.Mul(want, big.NewInt(p))
+		}
+	}
+	if got := bigger.Primorial(1000).String(); got != want.String() {
+		t.Errorf("primorial mismatch")
+	}
+	if got := bigger.Multinomial(3, 5, 7, 0, 2).String(); got != "49008960" {
+		t.Errorf("multinomial mismatch %s", got)
+	}
+
+	// F(n) and L(n) by the recurrences, also for negative n
+	f, g := big.NewInt(0), big.NewInt(1)
+	l, m := big.NewInt(2), big.NewInt(1)
+	for n := 0; n <= 700; n++ {
+		if n%7 == 0 || n > 690 {
+			sign := int64(1)
+			if n%2 == 0 {
+				sign = -1
+			}
+			if bigger.Fibonacci(types.Int(n)).String() != f.String() ||
+				bigger.Fibonacci(types.Int(-n)).String() != new(big.Int).Mul(f, big.NewInt(sign)).String() {
+				t.Errorf("fibonacci %d mismatch", n)
+			}
+			if bigger.Lucas(types.Int(n)).String() != l.String() ||
+				bigger.Lucas(types.Int(-n)).String() != new(big.Int).Mul(l, big.NewInt(-sign)).String() {
+				t.Errorf("lucas %d mismatch", n)
+			}
+		}
+		f, g = g, new(big.Int).Add(f, g)
+		l, m = m, new(big.Int).Add(l, m)
+	}
+
+	// S(n, k) = k S(n-1, k) + S(n-1, k-1), Bell(n) = sum S(n, k)
+	s := [][]*big.Int{{big.NewInt(1)}}
+	for n := 1; n <= 60; n++ {
+		row := make([]*big.Int, n+1)
+		for k := 0; k <= n; k++ {
+			row[k] = new(big.Int)
+			if k < n {
+				row[k].Mul(big.NewInt(int64(k)), s[n-1][k])
+			}
+			if k > 0 {
+				row[k].Add(row[k], s[n-1][k-1])
+			}
+		}
+		s = append(s, row)
+	}
+	for _, n := range []int{0, 1, 5, 31, 60} {
+		bell := new(big.Int)
+		for k := 0; k <= n; k++ {
+			bell.Add(bell, s[n][k])
+			if got := bigger.Stirling2(types.Int(n), types.Int(k)).String(); got != s[n][k].String() {
+				t.Errorf("stirling2 %d %d mismatch", n, k)
+			}
+		}
+		if got := bigger.Bell(types.Int(n)).String(); got != bell.String() {
+			t.Errorf("bell %d mismatch", n)
+		}
+		c := new(big.Int).Binomial(int64(2*n), int64(n))
+		if got := bigger.Catalan(types.Int(n)).String(); got != c.Div(c, big.NewInt(int64(n+1))).String() {
+			t.Errorf("catalan %d mismatch", n)
+		}
+	}
+}
+
+func BenchmarkFactorial(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bigger.Factorial(1000000)
+	}
+}
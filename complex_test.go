@@ -0,0 +1,252 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+var roundingModes = []bigger.RoundingMode{
+	bigger.ROUND_UP, bigger.ROUND_DOWN, bigger.ROUND_CEILING, bigger.ROUND_FLOOR,
+	bigger.ROUND_HALF_UP, bigger.ROUND_HALF_DOWN, bigger.ROUND_HALF_EVEN,
+}
+
+func ratOf(s string) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic(s)
+	}
+	return r
+}
+
+// roundRat returns x rounded to precision significant digits with the mode.
+func roundRat(x *big.Rat, precision int, mode bigger.RoundingMode) *big.Rat {
+	if x.Sign() == 0 {
+		return x
+	}
+	// 10^e <= |x| < 10^(e+1)
+	abs, e := new(big.Rat).Abs(x), 0
+	for abs.Cmp(new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(e+1)), nil))) >= 0 {
+		e++
+	}
+	for abs.Cmp(new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-e)), nil))) < 0 {
+		e--
+	}
+	scale := precision - 1 - e
+	unit := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs64(scale))), nil))
+	if scale < 0 {
+		unit.Inv(unit)
+	}
+	n := new(big.Rat).Mul(x, unit)
+	q, r := new(big.Int).QuoRem(n.Num(), n.Denom(), new(big.Int))
+	if r.Sign() != 0 {
+		half := new(big.Int).Abs(r)
+		half.Lsh(half, 1)
+		c := half.Cmp(n.Denom())
+		up := false
+		switch mode {
+		case bigger.ROUND_UP:
+			up = true
+		case bigger.ROUND_CEILING:
+			up = x.Sign() > 0
+		case bigger.ROUND_FLOOR:
+			up = x.Sign() < 0
+		case bigger.ROUND_HALF_UP:
+			up = c >= 0
+		case bigger.ROUND_HALF_DOWN:
+			up = c > 0
+		case bigger.ROUND_HALF_EVEN:
+			up = c > 0 || c == 0 && q.Bit(0) == 1
+		}
+		if up {
+			q.Add(q, big.NewInt(int64(x.Sign())))
+		}
+	}
+	return new(big.Rat).Quo(new(big.Rat).SetInt(q), unit)
+}
+
+func abs64(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// randomDecimal returns a decimal string of up to three ints of digits with
+// an exponent in [-30, 30).
+func randomDecimal(r *rand.Rand) string {
+	s := randomBigInt(r, 1+r.Intn(3)).String() + "7"
+	if r.Intn(2) == 0 {
+		s = s[:2+r.Intn(len(s)-1)]
+	}
+	return s + "E" + big.NewInt(int64(r.Intn(60)-30)).String()
+}
+
+// testing rounding and division with a math context
+func TestMathContext(t *testing.T) {
+	r := rand.New(rand.NewSource(44))
+	for i := 0; i < 3000; i++ {
+		x, y := bigger.NewBigDecimalString(randomDecimal(r)), bigger.NewBigDecimalString(randomDecimal(r))
+		rx, ry := ratOf(x.String()), ratOf(y.String())
+		if got := ratOf(x.Add(y).String()); got.Cmp(new(big.Rat).Add(rx, ry)) != 0 {
+			t.Fatalf("%v + %v mismatch: %v", x, y, x.Add(y))
+		}
+		if x.CompareTo(y) != types.Int(rx.Cmp(ry)) || x.Negate().CompareTo(y.Negate()) != types.Int(ry.Cmp(rx)) {
+			t.Fatalf("compare %v and %v mismatch", x, y)
+		}
+		if y.Signum() == 0 {
+			continue
+		}
+		p, mode := 1+r.Intn(40), roundingModes[r.Intn(len(roundingModes))]
+		mc := bigger.NewMathContext(types.Int(p), mode)
+		q := x.DivideMathContext(y, mc)
+		if q.Precision() > types.Int(p) || ratOf(q.String()).Cmp(roundRat(new(big.Rat).Quo(rx, ry), p, mode)) != 0 {
+			t.Fatalf("%v / %v to %d digits with mode %d mismatch: %v", x, y, p, mode, q)
+		}
+		if got := x.Round(mc); got.Precision() > types.Int(p) || ratOf(got.String()).Cmp(roundRat(rx, p, mode)) != 0 {
+			t.Fatalf("%v to %d digits with mode %d mismatch: %v", x, p, mode, got)
+		}
+	}
+
+	for _, c := range []struct{ x, y, want string }{
+		{"1", "8", "0.125"},
+		{"1E+3", "8", "125"},
+		{"100", "4", "25"},
+		{"1.00", "4", "0.25"},
+		{"-7.5", "0.25", "-3E+1"},
+	} {
+		if got := bigger.NewBigDecimalString(c.x).DivideMathContext(bigger.NewBigDecimalString(c.y), bigger.UNLIMITED); got.String() != c.want {
+			t.Errorf("%s / %s exact mismatch: %v", c.x, c.y, got)
+		}
+	}
+	// the periods of 1/7^2000 and its power run to about 7^2000 digits, the
+	// denominator alone tells they do not terminate
+	one, seven := bigger.NewBigDecimalString("1"), bigger.NewBigDecimalString("7")
+	for _, c := range []struct {
+		name string
+		f    func()
+	}{
+		{"1 / 3", func() { one.DivideMathContext(bigger.NewBigDecimalString("3"), bigger.UNLIMITED) }},
+		{"1 / 7^2000", func() { one.DivideMathContext(seven.Pow(2000), bigger.UNLIMITED) }},
+		{"7^-2000", func() { seven.PowDecimal(bigger.NewBigDecimalString("-2000"), bigger.UNLIMITED) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s exact did not panic", c.name)
+				}
+			}()
+			c.f()
+		}()
+	}
+	// far apart scales are told apart by the exponents, without aligning
+	for _, c := range []struct {
+		x, y string
+		want types.Int
+	}{
+		{"1E-30000000", "2E+30000000", -1},
+		{"-1E-30000000", "-2E+30000000", 1},
+		{"9.99E+1000000", "1E+1000001", -1},
+		{"1.000E+1000000", "1E+1000000", 0},
+		{"0E-2000000", "0E+2000000", 0},
+		{"-5E-2000000", "0E+2000000", -1},
+		{"123456789012345678901234567890E-5", "1234567890123456789012345.67891", -1},
+	} {
+		x, y := bigger.NewBigDecimalString(c.x), bigger.NewBigDecimalString(c.y)
+		if x.CompareTo(y) != c.want || y.CompareTo(x) != -c.want {
+			t.Errorf("compare %s and %s mismatch", c.x, c.y)
+		}
+	}
+	// a carry into a new digit yields the cached ten, which has two digits
+	if p := bigger.BigDecimalValueOf(10).Precision(); p != 2 {
+		t.Errorf("precision of 10 mismatch: %d", p)
+	}
+	for _, c := range []struct{ x, want string }{
+		{"9.77", "1E+1"},
+		{"9.5", "1E+1"},
+		{"-9.96", "-1E+1"},
+		{"99.5", "1E+2"},
+	} {
+		if got := bigger.NewBigDecimalString(c.x).Round(bigger.NewMathContext(1, bigger.ROUND_HALF_UP)); got.String() != c.want || got.Precision() != 1 {
+			t.Errorf("%s to 1 digit mismatch: %v", c.x, got)
+		}
+	}
+	if got := bigger.NewBigDecimalString("2").DivideMathContext(bigger.NewBigDecimalString("3"), bigger.DECIMAL32); got.String() != "0.6666667" {
+		t.Errorf("2 / 3 decimal32 mismatch: %v", got)
+	}
+	if got := bigger.NewBigDecimalString("2274305451656346514E+12").String(); got != "2.274305451656346514E+30" {
+		t.Errorf("string mismatch: %v", got)
+	}
+}
+
+// testing complex arithmetic against math/big
+func TestBigComplex(t *testing.T) {
+	for _, c := range []struct{ s, want string }{
+		{"1+2i", "1+2i"},
+		{"-1.5-2.25i", "-1.5-2.25i"},
+		{"3", "3+0i"},
+		{"-i", "0-1i"},
+		{"2.5e-3i", "0+0.0025i"},
+		{"1e+3 - 1E-2i", "1E+3-0.01i"},
+	} {
+		z := bigger.NewBigComplexString(c.s)
+		if z.String() != c.want || !bigger.NewBigComplexString(z.String()).Equals(z) {
+			t.Errorf("%s parse mismatch: %v", c.s, z)
+		}
+	}
+	if got := bigger.BigComplexValueOf(0.1, -1e-20).String(); got != "0.1-1E-20i" {
+		t.Errorf("float64 conversion mismatch: %v", got)
+	}
+	z := bigger.NewBigComplexString("1+2i")
+	if got := z.Pow(10, bigger.UNLIMITED); got.String() != "237-3116i" {
+		t.Errorf("(1+2i)^10 mismatch: %v", got)
+	}
+	if got := z.Pow(-2, bigger.DECIMAL32); got.String() != "-0.12-0.16i" {
+		t.Errorf("(1+2i)^-2 mismatch: %v", got)
+	}
+	if got := bigger.NewBigComplexString("3-4i").Abs(bigger.UNLIMITED); got.String() != "5" {
+		t.Errorf("|3-4i| mismatch: %v", got)
+	}
+
+	r := rand.New(rand.NewSource(45))
+	for i := 0; i < 500; i++ {
+		d := bigger.NewBigDecimalString
+		a := bigger.NewBigComplex(d(randomDecimal(r)), d(randomDecimal(r)))
+		b := bigger.NewBigComplex(d(randomDecimal(r)), d(randomDecimal(r)))
+		ar, ai, br, bi := ratOf(a.Real().String()), ratOf(a.Imag().String()), ratOf(b.Real().String()), ratOf(b.Imag().String())
+		check := func(what, got string, re, im *big.Rat) {
+			z := bigger.NewBigComplexString(got)
+			if ratOf(z.Real().String()).Cmp(re) != 0 || ratOf(z.Imag().String()).Cmp(im) != 0 {
+				t.Fatalf("%s of %v and %v mismatch: %v", what, a, b, got)
+			}
+		}
+		check("sum", a.Add(b).String(), new(big.Rat).Add(ar, br), new(big.Rat).Add(ai, bi))
+		check("difference", a.Subtract(b).String(), new(big.Rat).Sub(ar, br), new(big.Rat).Sub(ai, bi))
+		pr := new(big.Rat).Sub(new(big.Rat).Mul(ar, br), new(big.Rat).Mul(ai, bi))
+		pi := new(big.Rat).Add(new(big.Rat).Mul(ar, bi), new(big.Rat).Mul(ai, br))
+		check("product", a.Multiply(b).String(), pr, pi)
+		check("conjugate", a.Conjugate().String(), ar, new(big.Rat).Neg(ai))
+
+		p, mode := 1+r.Intn(40), roundingModes[r.Intn(len(roundingModes))]
+		mc := bigger.NewMathContext(types.Int(p), mode)
+		norm := new(big.Rat).Add(new(big.Rat).Mul(br, br), new(big.Rat).Mul(bi, bi))
+		if norm.Sign() != 0 {
+			qr := new(big.Rat).Add(new(big.Rat).Mul(ar, br), new(big.Rat).Mul(ai, bi))
+			qi := new(big.Rat).Sub(new(big.Rat).Mul(ai, br), new(big.Rat).Mul(ar, bi))
+			check("quotient", a.Divide(b, mc).String(), roundRat(qr.Quo(qr, norm), p, mode), roundRat(qi.Quo(qi, norm), p, mode))
+		}
+
+		// the truncated magnitude s has s^2 <= |a|^2 < (s + ulp)^2
+		s := a.Abs(bigger.NewMathContext(types.Int(p), bigger.ROUND_DOWN))
+		sr := ratOf(s.String())
+		ulp := ratOf("1E" + big.NewInt(int64(-s.Scale())).String())
+		n := new(big.Rat).Add(new(big.Rat).Mul(ar, ar), new(big.Rat).Mul(ai, ai))
+		next := new(big.Rat).Add(sr, ulp)
+		if s.Precision() > types.Int(p) || new(big.Rat).Mul(sr, sr).Cmp(n) > 0 || new(big.Rat).Mul(next, next).Cmp(n) <= 0 {
+			t.Fatalf("|%v| to %d digits mismatch: %v", a, p, s)
+		}
+	}
+}
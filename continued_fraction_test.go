@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing continued fractions, best approximations and simplest fractions
+func TestContinuedFraction(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for _, size := range []int{1, 3, 20} {
+		for k := 0; k < 10; k++ {
+			x := new(big.Rat).SetFrac(randomBigInt(r, size), new(big.Int).Add(new(big.Int).Abs(randomBigInt(r, size)), big.NewInt(1)))
+			bx := bigger.NewBigRationalString(x.RatString())
+			terms := bx.ContinuedFraction()
+			if back := bigger.FromContinuedFraction(terms); !back.Equals(bx) {
+				t.Errorf("continued fraction of %d ints mismatch", size)
+			}
+			// the convergents follow the terms and end in x
+			c := bigger.NewConvergents(bx)
+			for i := 0; ; i++ {
+				v, ok := c.Next()
+				if !ok {
+					if i != len(terms) {
+						t.Errorf("%d convergents for %d terms", i, len(terms))
+					}
+					break
+				}
+				if c.Term().CompareTo(terms[i]) != 0 {
+					t.Errorf("convergent term %d of %d ints mismatch", i, size)
+				}
+				if i == len(terms)-1 && !v.Equals(bx) {
+					t.Errorf("last convergent of %d ints mismatch", size)
+				}
+			}
+
+			// no fraction with a denominator up to the limit is closer
+			limit := int64(1 + r.Intn(500))
+			best := bigger.BestApproximation(bx, bigger.BigIntegerValueOf(types.Long(limit)))
+			bv, _ := new(big.Rat).SetString(best.String())
+			dist := new(big.Rat).Abs(new(big.Rat).Sub(bv, x))
+			for q := int64(1); q <= limit; q++ {
+				p := new(big.Int).Div(new(big.Int).Mul(x.Num(), big.NewInt(q)), x.Denom())
+				for _, pp := range []*big.Int{p, new(big.Int).Add(p, big.NewInt(1))} {
+					if d := new(big.Rat).Abs(new(big.Rat).Sub(new(big.Rat).SetFrac(pp, big.NewInt(q)), x)); d.Cmp(dist) < 0 {
+						t.Errorf("best approximation of %d ints with limit %d mismatch", size, limit)
+					}
+				}
+			}
+		}
+	}
+
+	v, q := bigger.BigRationalValueOf, bigger.NewBigRationalString
+	for _, c := range []struct{ a, b, want string }{
+		{"3.14159", "3.1416", "355/113"},
+		{"1/3", "1/2", "1/2"},
+		{"0.33", "0.34", "1/3"},
+		{"-7/4", "-1.7", "-7/4"},
+		{"-1.74", "-1.71", "-12/7"},
+		{"-1/2", "1/3", "0"},
+		{"5/2", "7/2", "3"},
+	} {
+		if got := bigger.SimplestBetween(q(c.a), q(c.b)); got.String() != c.want {
+			t.Errorf("simplest between %s and %s mismatch: %v", c.a, c.b, got)
+		}
+	}
+	if got := bigger.BestApproximation(q("3.141592653589793"), bigger.BigIntegerValueOf(1000)); !got.Equals(v(355, 113)) {
+		t.Errorf("best approximation of pi mismatch: %v", got)
+	}
+	if got := bigger.NewBigDecimalString("-0.75").ContinuedFraction(); len(got) != 2 || got[0].LongValue() != -1 || got[1].LongValue() != 4 {
+		t.Errorf("continued fraction of -0.75 mismatch: %v", got)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+)
+
+// testing the chinese remainder theorem
+func TestCRT(t *testing.T) {
+	r := rand.New(rand.NewSource(35))
+	for _, size := range []int{1, 3, 20} {
+		// the moduli share a random factor, the residues come from a known solution
+		x := new(big.Int).Abs(randomBigInt(r, 3*size))
+		common := new(big.Int).Abs(randomBigInt(r, 1))
+		residues, moduli, lcm := bigger.BigIntegers(), bigger.BigIntegers(), big.NewInt(1)
+		for k := 0; k < 5; k++ {
+			m := new(big.Int).Abs(randomBigInt(r, size))
+			m.Add(m, big.NewInt(1))
+			if k%2 == 0 {
+				m.Mul(m, common)
+			}
+			moduli = append(moduli, bigger.NewBigIntegerString(m.String()))
+			residues = append(residues, bigger.NewBigIntegerString(new(big.Int).Sub(new(big.Int).Mod(x, m), m).String()))
+			lcm.Div(new(big.Int).Mul(lcm, m), new(big.Int).GCD(nil, nil, lcm, m))
+		}
+		sol, m, err := bigger.CRT(residues, moduli)
+		if err != nil || m.String() != lcm.String() || sol.String() != new(big.Int).Mod(x, lcm).String() {
+			t.Errorf("crt of %d ints mismatch: %v", size, err)
+		}
+
+		// Garner on coprime moduli
+		primes := bigger.BigIntegers()
+		for p := new(big.Int).Abs(randomBigInt(r, size)); len(primes) < 6; p.Add(p, big.NewInt(1)) {
+			if p.ProbablyPrime(20) {
+				primes = append(primes, bigger.NewBigIntegerString(p.String()))
+			}
+		}
+		basis := bigger.NewCRTBasis(primes)
+		y := bigger.NewBigIntegerString(randomBigInt(r, 6*size).String()).Mod(basis.Modulus())
+		if got := basis.Combine(basis.Residues(y)); got.CompareTo(y) != 0 {
+			t.Errorf("garner of %d ints mismatch", size)
+		}
+	}
+
+	v := bigger.BigIntegerValueOf
+	if _, _, err := bigger.CRT(bigger.BigIntegers(v(1), v(2)), bigger.BigIntegers(v(4), v(6))); err != bigger.ErrInconsistentCongruences {
+		t.Errorf("inconsistent crt mismatch: %v", err)
+	}
+	if x, m, err := bigger.CRT(bigger.BigIntegers(v(3), v(-1)), bigger.BigIntegers(v(4), v(6))); err != nil || x.LongValue() != 11 || m.LongValue() != 12 {
+		t.Errorf("crt 3 mod 4, -1 mod 6 mismatch")
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing constant-time modPow, comparison, select and swap, bigger.bigInteger vs bigInt
+func TestModPowCT(t *testing.T) {
+	r := rand.New(rand.NewSource(38))
+	for _, size := range []int{1, 2, 7, 20} {
+		for k := 0; k < 10; k++ {
+			m := new(big.Int).Abs(randomBigInt(r, size))
+			m.SetBit(m, 0, 1).SetBit(m, 32*size-1, 1)
+			a, e := randomBigInt(r, size+k%3), new(big.Int).Abs(randomBigInt(r, k%4))
+			want := new(big.Int).Exp(new(big.Int).Mod(a, m), e, m)
+			ba, be, bm := bigger.NewBigIntegerString(a.String()), bigger.NewBigIntegerString(e.String()), bigger.NewBigIntegerString(m.String())
+			if got := ba.ModPowCTBits(be, bm, 100); got.String() != want.String() {
+				t.Errorf("modPowCT of %d ints mismatch", size)
+			}
+			if e.BitLen() <= m.BitLen() {
+				if got := ba.ModPowCT(be, bm); got.String() != want.String() {
+					t.Errorf("modPowCT with the bits of m of %d ints mismatch", size)
+				}
+			}
+
+			x, y := randomBigInt(r, size), randomBigInt(r, size-k%2)
+			if k%4 == 0 {
+				y.Set(x)
+			}
+			bx, by := bigger.NewBigIntegerString(x.String()), bigger.NewBigIntegerString(y.String())
+			if bigger.CompareCT(bx, by) != types.Int(x.Cmp(y)) || bigger.EqualCT(bx, by) != (x.Cmp(y) == 0) {
+				t.Errorf("compareCT of %d ints mismatch", size)
+			}
+			fx, fy := bigger.NewFixedMagnitude(bx.Abs(), types.Int(size)), bigger.NewFixedMagnitude(by.Abs(), types.Int(size))
+			if fx.Compare(fy) != types.Int(new(big.Int).Abs(x).Cmp(new(big.Int).Abs(y))) {
+				t.Errorf("fixed compare of %d ints mismatch", size)
+			}
+			cond := types.Int(k & 1)
+			if s := bigger.NewFixedMagnitude(bigger.ZERO, types.Int(size)).Select(cond, fx, fy); s.Equal(fx) != (cond == 1 || fx.Equal(fy)) {
+				t.Errorf("select of %d ints mismatch", size)
+			}
+			fx.Swap(cond, fy)
+			if want := [2]*big.Int{y, x}[cond]; fy.BigInteger().String() != new(big.Int).Abs(want).String() {
+				t.Errorf("swap of %d ints mismatch", size)
+			}
+		}
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("modPowCT of an exponent longer than the bit count did not panic")
+			}
+		}()
+		bigger.BigIntegerValueOf(2).ModPowCTBits(bigger.BigIntegerValueOf(16), bigger.BigIntegerValueOf(7), 4)
+	}()
+}
+
+// testing that the time of modPowCT does not depend on the exponent: short
+// and random full length exponents are timed in random order and their
+// distributions compared by Welch's t-test, as dudect does
+func TestModPowCTTiming(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	r := rand.New(rand.NewSource(38))
+	m := new(big.Int).Abs(randomBigInt(r, 8))
+	m.SetBit(m, 0, 1).SetBit(m, 255, 1)
+	bm := bigger.NewBigIntegerString(m.String())
+	short := bigger.BigIntegerValueOf(3)
+
+	var samples [2][]float64
+	for k := 0; k < 1000; k++ {
+		class := r.Intn(2)
+		e := short
+		if class == 1 {
+			x := new(big.Int).Abs(randomBigInt(r, 8))
+			e = bigger.NewBigIntegerString(x.SetBit(x, 255, 1).String())
+		}
+		base := bigger.NewBigIntegerString(new(big.Int).Mod(new(big.Int).Abs(randomBigInt(r, 8)), m).String())
+		start := time.Now()
+		base.ModPowCT(e, bm)
+		samples[class] = append(samples[class], float64(time.Since(start)))
+	}
+	// drop the slowest tenth of either class, those are scheduling noise
+	for i := range samples {
+		sort.Float64s(samples[i])
+		samples[i] = samples[i][:len(samples[i])*9/10]
+	}
+	if tt := welch(samples[0], samples[1]); math.Abs(tt) > 10 {
+		t.Errorf("modPowCT time depends on the exponent, t = %.1f", tt)
+	} else {
+		t.Logf("t = %.1f", tt)
+	}
+}
+
+// welch returns Welch's t statistic of two samples
+func welch(a, b []float64) float64 {
+	mean := func(x []float64) (float64, float64) {
+		var s, ss float64
+		for _, v := range x {
+			s += v
+		}
+		mu := s / float64(len(x))
+		for _, v := range x {
+			ss += (v - mu) * (v - mu)
+		}
+		return mu, ss / float64(len(x)-1)
+	}
+	ma, va := mean(a)
+	mb, vb := mean(b)
+	return (ma - mb) / math.Sqrt(va/float64(len(a))+vb/float64(len(b)))
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing exp, logarithms and real powers against known values and their own
+// results at a higher precision
+func TestBigDecimalExp(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	d20 := bigger.NewMathContext(20, bigger.ROUND_HALF_EVEN)
+	for _, c := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"exp 1", d("1").Exp(bigger.DECIMAL128).String(), "2.718281828459045235360287471352662"},
+		{"exp -1", d("-1").Exp(bigger.DECIMAL128).String(), "0.3678794411714423215955237701614609"},
+		{"ln 2", d("2").Ln(bigger.DECIMAL128).String(), "0.6931471805599453094172321214581766"},
+		{"ln 10", d("10").Ln(bigger.DECIMAL128).String(), "2.302585092994045684017991454684364"},
+		{"log10 2", d("2").Log10(bigger.DECIMAL128).String(), "0.3010299956639811952137388947244930"},
+		{"2^0.5", d("2").PowDecimal(d("0.5"), bigger.DECIMAL128).String(), "1.414213562373095048801688724209698"},
+		{"1.05^30.5", d("1.05").PowDecimal(d("30.5"), bigger.DECIMAL128).String(), "4.428673073148332198055978240016341"},
+		{"exp 100", d("100").Exp(d20).String(), "2.6881171418161354484E+43"},
+		{"exp -100", d("-100").Exp(d20).String(), "3.7200759760208359630E-44"},
+		{"ln 1E-100", d("1E-100").Ln(d20).String(), "-230.25850929940456840"},
+		{"ln near one", d("1.00000000000000000000001").Ln(d20).String(), "1.0000000000000000000E-23"},
+	} {
+		if ratOf(c.got).Cmp(ratOf(c.want)) != 0 {
+			t.Errorf("%s mismatch: %v", c.name, c.got)
+		}
+	}
+
+	for _, c := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"exp 0", d("0").Exp(bigger.UNLIMITED).String(), "1"},
+		{"ln 1", d("1.000").Ln(bigger.UNLIMITED).String(), "0"},
+		{"log10 0.001", d("0.001").Log10(bigger.UNLIMITED).String(), "-3"},
+		{"log10 1E+7", d("1E+7").Log10(bigger.DECIMAL32).String(), "7"},
+		{"log_4 8", d("8").Log(d("4"), bigger.UNLIMITED).String(), "1.5"},
+		{"log_8 4", d("4").Log(d("8"), bigger.NewMathContext(5, bigger.ROUND_UP)).String(), "0.66667"},
+		{"log_1.1 1.21", d("1.21").Log(d("1.1"), bigger.NewMathContext(5, bigger.ROUND_FLOOR)).String(), "2"},
+		{"log_0.01 1000", d("1000").Log(d("0.01"), bigger.DECIMAL32).String(), "-1.5"},
+		{"4^0.5", d("4").PowDecimal(d("0.5"), bigger.UNLIMITED).String(), "2"},
+		{"0.0001^0.25", d("0.0001").PowDecimal(d("0.25"), bigger.DECIMAL32).String(), "0.1"},
+		{"1.44^1.5", d("1.44").PowDecimal(d("1.5"), bigger.NewMathContext(3, bigger.ROUND_DOWN)).String(), "1.72"},
+		{"-2^-3", d("-2").PowDecimal(d("-3"), bigger.UNLIMITED).String(), "-0.125"},
+		{"-2^3", d("-2").PowDecimal(d("3"), bigger.NewMathContext(1, bigger.ROUND_CEILING)).String(), "-8"},
+		{"1.1^3", d("1.1").PowDecimal(d("3"), bigger.NewMathContext(3, bigger.ROUND_UP)).String(), "1.34"},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s mismatch: %v", c.name, c.got)
+		}
+	}
+
+	// ln inverts exp at a thousand digits
+	mc := bigger.NewMathContext(1000, bigger.ROUND_HALF_EVEN)
+	x := d("2.5")
+	y := x.Exp(mc).Ln(mc)
+	if diff := ratOf(y.Subtract(x).Abs().String()); diff.Cmp(ratOf("1E-996")) > 0 {
+		t.Errorf("ln exp 2.5 at 1000 digits mismatch: %v", y)
+	}
+
+	r := rand.New(rand.NewSource(47))
+	for i := 0; i < 600; i++ {
+		s := strings.TrimPrefix(randomDecimal(r), "-")
+		b := d(s)
+		if b.Signum() == 0 {
+			continue
+		}
+		p, mode := 1+r.Intn(40), roundingModes[r.Intn(len(roundingModes))]
+		mc := bigger.NewMathContext(types.Int(p), mode)
+		high := bigger.NewMathContext(types.Int(p+30), bigger.ROUND_HALF_EVEN)
+		check := func(what string, got, want interface {
+			Precision() types.Int
+			String() string
+		}) {
+			if got.Precision() > types.Int(p) || ratOf(got.String()).Cmp(roundRat(ratOf(want.String()), p, mode)) != 0 {
+				t.Fatalf("%s of %s to %d digits with mode %d mismatch: %v", what, s, p, mode, got)
+			}
+		}
+		y := d(big.NewInt(int64(r.Intn(2000)-1000)).String() + "E-2")
+		if b.Precision()-b.Scale() <= 3 {
+			check("exp", b.Exp(mc), b.Exp(high))
+			check("power 3.25 of", d("3.25").PowDecimal(b, mc), d("3.25").PowDecimal(b, high))
+		}
+		if b.CompareTo(d("1")) != 0 {
+			check("ln", b.Ln(mc), b.Ln(high))
+			check("log10", b.Log10(mc), b.Log10(high))
+			check("log to 7.5", b.Log(d("7.5"), mc), b.Log(d("7.5"), high))
+			check("power "+y.String(), b.PowDecimal(y, mc), b.PowDecimal(y, high))
+		}
+	}
+}
+
+// testing exp of arguments so small that e^x rounds like 1 + x, against
+// the Taylor polynomial of degree three
+func TestBigDecimalExpTiny(t *testing.T) {
+	for _, s := range []string{
+		"1E-20000", "-1E-20000", "1.234567890123456789012345678901234567E-20000",
+		"3.1E-19", "-7E-18", "1E-17", "-1E-17", "1.5E-17", "9.999E-16", "-1E-16",
+	} {
+		x := ratOf(s)
+		x2 := new(big.Rat).Mul(x, x)
+		want := new(big.Rat).Add(big.NewRat(1, 1), x)
+		want.Add(want, new(big.Rat).Mul(x2, big.NewRat(1, 2)))
+		want.Add(want, new(big.Rat).Mul(new(big.Rat).Mul(x2, x), big.NewRat(1, 6)))
+		for _, p := range []int{1, 3, 16} {
+			for _, mode := range roundingModes {
+				got := bigger.NewBigDecimalString(s).Exp(bigger.NewMathContext(types.Int(p), mode))
+				if got.Precision() > types.Int(p) || ratOf(got.String()).Cmp(roundRat(want, p, mode)) != 0 {
+					t.Errorf("exp %s to %d digits with mode %d mismatch: %v", s, p, mode, got)
+				}
+			}
+		}
+	}
+}
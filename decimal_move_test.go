@@ -0,0 +1,85 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing moves of the decimal point, which change the scale only
+func TestBigDecimalMovePoint(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"cents to dollars", d("12345").MovePointLeft(2).String(), "123.45"},
+		{"dollars to cents", d("123.45").MovePointRight(2).String(), "12345"},
+		{"satoshi to btc", d("150000").MovePointLeft(8).String(), "0.00150000"},
+		{"right past the digits", d("1.5").MovePointRight(3).String(), "1500"},
+		{"left by a negative", d("1E+3").MovePointLeft(-1).String(), "10000"},
+		{"right by a negative", d("-2.5").MovePointRight(-2).String(), "-0.025"},
+		{"zero places", d("1E+3").MovePointLeft(0).String(), "1E+3"},
+		{"zero right", d("0.00").MovePointRight(5).String(), "0"},
+		{"scale up", d("1.5").ScaleByPowerOfTen(3).String(), "1.5E+3"},
+		{"scale down", d("12.30").ScaleByPowerOfTen(-2).String(), "0.1230"},
+		{"scale zero", d("0").ScaleByPowerOfTen(4).String(), "0E+4"},
+		{"large unscaled", d("123456789012345678901234567890").MovePointLeft(25).String(), "12345.6789012345678901234567890"},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s mismatch: %v", c.name, c.got)
+		}
+	}
+
+	for _, c := range []struct {
+		name string
+		f    func()
+	}{
+		{"left past the scale", func() { d("1E-2147483647").MovePointLeft(2) }},
+		{"right past the scale", func() { d("1E+2147483647").MovePointRight(2) }},
+		{"scale past the scale", func() { d("1E-2147483647").ScaleByPowerOfTen(-2) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", c.name)
+				}
+			}()
+			c.f()
+		}()
+	}
+
+	r := rand.New(rand.NewSource(50))
+	for i := 0; i < 1000; i++ {
+		s := randomDecimal(r)
+		b := d(s)
+		n := r.Intn(80) - 40
+		pow := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs64(n))), nil))
+		if n < 0 {
+			pow.Inv(pow)
+		}
+		left, right, scaled := b.MovePointLeft(types.Int(n)), b.MovePointRight(types.Int(n)), b.ScaleByPowerOfTen(types.Int(n))
+		if got, want := ratOf(left.String()), new(big.Rat).Quo(ratOf(s), pow); got.Cmp(want) != 0 || left.Scale() != maxScale(b.Scale(), n) {
+			t.Fatalf("%s moved %d left mismatch: %v", s, n, left)
+		}
+		if got, want := ratOf(right.String()), new(big.Rat).Mul(ratOf(s), pow); got.Cmp(want) != 0 || right.Scale() != maxScale(b.Scale(), -n) {
+			t.Fatalf("%s moved %d right mismatch: %v", s, n, right)
+		}
+		if scaled.UnscaledValue().String() != b.UnscaledValue().String() || scaled.Scale() != b.Scale()-types.Int(n) {
+			t.Fatalf("%s scaled by 10^%d mismatch: %v", s, n, scaled)
+		}
+	}
+}
+
+// maxScale returns the scale of a moved point, b itself for n = 0
+func maxScale(scale types.Int, n int) types.Int {
+	s := scale + types.Int(n)
+	if s < 0 && n != 0 {
+		return 0
+	}
+	return s
+}
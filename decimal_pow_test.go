@@ -0,0 +1,93 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing integer powers of decimals, exact and under a math context
+func TestBigDecimalPow(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"1.1^3", d("1.1").Pow(3).String(), "1.331"},
+		{"1.10^2", d("1.10").Pow(2).String(), "1.2100"},
+		{"-2^5", d("-2").Pow(5).String(), "-32"},
+		{"1E+2^3", d("1E+2").Pow(3).String(), "1E+6"},
+		{"0.00^0", d("0.00").Pow(0).String(), "1"},
+		{"0.0^3", d("0.0").Pow(3).String(), "0.000"},
+		{"1.05^360", d("1.05").PowMathContext(360, bigger.DECIMAL64).String(), "42476396.40868002"},
+		{"1.0001^-1000", d("1.0001").PowMathContext(-1000, bigger.DECIMAL32).String(), "0.9048419"},
+		{"2^-3", d("2").PowMathContext(-3, bigger.DECIMAL64).String(), "0.125"},
+		{"-3^101", d("-3").PowMathContext(101, bigger.DECIMAL128).String(), "-1.546132562196033993109383389296864E+48"},
+		{"0.5^200", d("0.5").PowMathContext(200, bigger.NewMathContext(7, bigger.ROUND_DOWN)).String(), "6.223015E-61"},
+		{"10^-20", d("10").PowMathContext(-20, bigger.NewMathContext(3, bigger.ROUND_HALF_UP)).String(), "1E-20"},
+		{"1.1^3 unlimited", d("1.1").PowMathContext(3, bigger.UNLIMITED).String(), "1.331"},
+		{"7^0", d("7.00").PowMathContext(0, bigger.DECIMAL32).String(), "1"},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s mismatch: %v", c.name, c.got)
+		}
+	}
+
+	for _, c := range []struct {
+		name string
+		f    func()
+	}{
+		{"negative exact power", func() { d("2").Pow(-1) }},
+		{"huge exact power", func() { d("2").Pow(1000000000) }},
+		{"scale overflow", func() { d("1E-1000000000").Pow(3) }},
+		{"negative unlimited power", func() { d("2").PowMathContext(-1, bigger.UNLIMITED) }},
+		{"exponent longer than precision", func() { d("2").PowMathContext(1000, bigger.NewMathContext(3, bigger.ROUND_HALF_UP)) }},
+		{"inverse of zero", func() { d("0").PowMathContext(-2, bigger.DECIMAL32) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", c.name)
+				}
+			}()
+			c.f()
+		}()
+	}
+
+	// exact powers against math/big, and rounded ones within two units of the
+	// last digit
+	r := rand.New(rand.NewSource(49))
+	for i := 0; i < 500; i++ {
+		s := randomDecimal(r)
+		b := d(s)
+		n := r.Intn(40)
+		got := b.Pow(types.Int(n))
+		want := new(big.Rat).SetInt64(1)
+		for k := 0; k < n; k++ {
+			want.Mul(want, ratOf(s))
+		}
+		if ratOf(got.String()).Cmp(want) != 0 || got.Scale() != b.Scale()*types.Int(n) {
+			t.Fatalf("%s^%d mismatch: %v", s, n, got)
+		}
+		if b.Signum() == 0 {
+			continue
+		}
+		if r.Intn(2) == 0 {
+			n = -n
+			want.Inv(want)
+		}
+		p, mode := 4+r.Intn(40), roundingModes[r.Intn(len(roundingModes))]
+		rounded := b.PowMathContext(types.Int(n), bigger.NewMathContext(types.Int(p), mode))
+		if rounded.Precision() > types.Int(p) {
+			t.Fatalf("%s^%d to %d digits has %d digits", s, n, p, rounded.Precision())
+		}
+		ulp := ratOf("1E" + big.NewInt(int64(rounded.Precision()-rounded.Scale()-types.Int(p))).String())
+		if diff := new(big.Rat).Sub(ratOf(rounded.String()), want); diff.Abs(diff).Cmp(ulp.Mul(ulp, big.NewRat(2, 1))) > 0 {
+			t.Fatalf("%s^%d to %d digits with mode %d mismatch: %v", s, n, p, mode, rounded)
+		}
+	}
+}
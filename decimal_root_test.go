@@ -0,0 +1,120 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// ratPow returns x^n.
+func ratPow(x *big.Rat, n int) *big.Rat {
+	p := new(big.Rat).SetInt64(1)
+	for i := 0; i < n; i++ {
+		p.Mul(p, x)
+	}
+	return p
+}
+
+// checkRounded reports whether the positive q, rounded to precision digits
+// from the positive root of x = t^n, is the rounding of t with the mode.
+func checkRounded(q, x *big.Rat, n, precision int, mode bigger.RoundingMode) bool {
+	if ratPow(q, n).Cmp(x) == 0 {
+		return true
+	}
+	// ulp at the precision, the step below a power of ten is ten times finer
+	e := 0
+	for ratOf("1E"+big.NewInt(int64(e+1)).String()).Cmp(q) <= 0 {
+		e++
+	}
+	for ratOf("1E"+big.NewInt(int64(e)).String()).Cmp(q) > 0 {
+		e--
+	}
+	ulp := ratOf("1E" + big.NewInt(int64(e-precision+1)).String())
+	below := ulp
+	if ratOf("1E"+big.NewInt(int64(e)).String()).Cmp(q) == 0 {
+		below = new(big.Rat).Quo(ulp, big.NewRat(10, 1))
+	}
+	up, down := new(big.Rat).Add(q, ulp), new(big.Rat).Sub(q, below)
+	switch mode {
+	case bigger.ROUND_DOWN:
+		return ratPow(q, n).Cmp(x) < 0 && x.Cmp(ratPow(up, n)) < 0
+	case bigger.ROUND_UP:
+		return ratPow(down, n).Cmp(x) < 0 && x.Cmp(ratPow(q, n)) < 0
+	}
+	half := big.NewRat(1, 2)
+	lo := new(big.Rat).Mul(new(big.Rat).Add(down, q), half)
+	hi := new(big.Rat).Mul(new(big.Rat).Add(q, up), half)
+	return ratPow(lo, n).Cmp(x) <= 0 && x.Cmp(ratPow(hi, n)) <= 0
+}
+
+// testing decimal roots against math/big
+func TestBigDecimalRoot(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range []struct {
+		x    string
+		n    types.Int
+		want string
+	}{
+		{"0.25", 2, "0.5"},
+		{"4.00", 2, "2.0"},
+		{"1E+6", 2, "1E+3"},
+		{"-27", 3, "-3"},
+		{"0.001", 3, "0.1"},
+		{"16", 4, "2"},
+		{"0", 3, "0"},
+	} {
+		if got := d(c.x).Root(c.n, bigger.UNLIMITED); got.String() != c.want {
+			t.Errorf("root %d of %s mismatch: %v", c.n, c.x, got)
+		}
+	}
+	if got := d("2").Sqrt(bigger.DECIMAL128); got.String() != "1.414213562373095048801688724209698" {
+		t.Errorf("sqrt 2 mismatch: %v", got)
+	}
+	if got := d("-2").Cbrt(bigger.NewMathContext(10, bigger.ROUND_FLOOR)); got.String() != "-1.259921050" {
+		t.Errorf("cbrt -2 mismatch: %v", got)
+	}
+	// 2^400 + 2^336, whose sparse top ints rounded the integer root low
+	x := d("2582249878086908589795903218389124637489545935364751280213262200117813217948090513439882345228897890522208015302222938112")
+	if got := x.Sqrt(bigger.NewMathContext(30, bigger.ROUND_HALF_EVEN)); got.String() != "1.60693804425899027558551823531E+60" {
+		t.Errorf("sqrt 2^400 + 2^336 mismatch: %v", got)
+	}
+	one := big.NewInt(1)
+	for e := uint(70); e < 700; e += 41 {
+		for f := uint(0); f < e; f += 31 {
+			s := new(big.Int).Add(new(big.Int).Lsh(one, e), new(big.Int).Lsh(one, f)).String()
+			for _, n := range []int{2, 3} {
+				q := d(s).Root(types.Int(n), bigger.NewMathContext(30, bigger.ROUND_HALF_EVEN))
+				if !checkRounded(ratOf(q.String()), ratOf(s), n, 30, bigger.ROUND_HALF_EVEN) {
+					t.Fatalf("root %d of 2^%d + 2^%d mismatch: %v", n, e, f, q)
+				}
+			}
+		}
+	}
+
+	r := rand.New(rand.NewSource(46))
+	for i := 0; i < 1500; i++ {
+		s := strings.TrimPrefix(randomDecimal(r), "-")
+		n := 2 + r.Intn(4)
+		negative := n%2 == 1 && r.Intn(2) == 0
+		if negative {
+			s = "-" + s
+		}
+		p, mode := 1+r.Intn(30), roundingModes[r.Intn(len(roundingModes))]
+		q := d(s).Root(types.Int(n), bigger.NewMathContext(types.Int(p), mode))
+		// on the magnitudes, ceiling and floor round up or down
+		switch {
+		case mode == bigger.ROUND_CEILING && !negative, mode == bigger.ROUND_FLOOR && negative:
+			mode = bigger.ROUND_UP
+		case mode == bigger.ROUND_CEILING, mode == bigger.ROUND_FLOOR:
+			mode = bigger.ROUND_DOWN
+		}
+		x, qr := new(big.Rat).Abs(ratOf(s)), ratOf(q.String())
+		if q.Precision() > types.Int(p) || (qr.Sign() < 0) != negative || !checkRounded(qr.Abs(qr), x, n, p, mode) {
+			t.Fatalf("root %d of %s to %d digits with mode %d mismatch: %v", n, s, p, mode, q)
+		}
+	}
+}
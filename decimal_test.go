@@ -0,0 +1,127 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing Subtract of a compact from an inflated decimal, which borrows
+// across ints
+func TestBigDecimalSubtractBorrow(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range [][3]string{
+		{"18446744073709551616", "1", "18446744073709551615"},
+		{"340282366920938463463374607431768211456", "1", "340282366920938463463374607431768211455"},
+		{"1", "340282366920938463463374607431768211456", "-340282366920938463463374607431768211455"},
+	} {
+		if got := d(c[0]).Subtract(d(c[1])).String(); got != c[2] {
+			t.Errorf("%s - %s mismatch: %v", c[0], c[1], got)
+		}
+	}
+}
+
+// testing Subtract of a compact from an inflated decimal of the other sign,
+// which adds the magnitudes
+func TestBigDecimalSubtractCarry(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range [][3]string{
+		{"-123456789012345678901234567890", "1", "-123456789012345678901234567891"},
+		{"-18446744073709551615", "1", "-18446744073709551616"},
+	} {
+		if got := d(c[0]).Subtract(d(c[1])).String(); got != c[2] {
+			t.Errorf("%s - %s mismatch: %v", c[0], c[1], got)
+		}
+	}
+}
+
+// testing Subtract of a negative compact from an inflated decimal, and of a
+// compact beyond 53 bits
+func TestBigDecimalSubtractSigns(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range [][3]string{
+		{"123456789012345678901234567890", "-1", "123456789012345678901234567891"},
+		{"-123456789012345678901234567890", "4611686018427387905", "-123456789016957364919661955795"},
+		{"123456789012345678901234567890", "-4611686018427387905", "123456789016957364919661955795"},
+	} {
+		if got := d(c[0]).Subtract(d(c[1])).String(); got != c[2] {
+			t.Errorf("%s - %s mismatch: %v", c[0], c[1], got)
+		}
+	}
+}
+
+// testing Divide by a compact of two ints, which divides the magnitude by a long
+func TestBigDecimalDivideLong(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range [][3]string{
+		{"61403400731793936489634", "683256728016174825", "89868.70997974325959763"},
+		{"-123456789012345678901234567890", "683256728016174825", "-180688727897.05346355261518932"},
+		{"123456789012345678901234567890", "98765432109", "1249999988620468750.05420392566188311"},
+	} {
+		if got := d(c[0]).Divide(d(c[1]), 17, bigger.ROUND_DOWN).String(); got != c[2] {
+			t.Errorf("%s / %s mismatch: %v", c[0], c[1], got)
+		}
+	}
+}
+
+// testing String of compacts beyond the 53 bits a float64 holds exactly
+func TestBigDecimalStringCompact(t *testing.T) {
+	for _, s := range []string{"900719925474099.3", "-900719925474099.3", "9.007199254740993", "1234567890123456.78", "-9007199254740993"} {
+		if got := bigger.NewBigDecimalString(s).String(); got != s {
+			t.Errorf("%s mismatch: %v", s, got)
+		}
+	}
+}
+
+// testing Add with inflated operands
+func TestBigDecimalAddInflated(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range [][3]string{
+		{"12345678901234567890123", "1", "12345678901234567890124"},
+		{"1", "12345678901234567890123", "12345678901234567890124"},
+		{"12345678901234567890123", "12345678901234567890123", "24691357802469135780246"},
+		{"9223372036854775807", "1", "9223372036854775808"},
+	} {
+		if got := d(c[0]).Add(d(c[1])); got == nil || got.String() != c[2] {
+			t.Errorf("%s + %s mismatch: %v", c[0], c[1], got)
+		}
+	}
+}
+
+// testing Add of compacts whose rescaled augend no longer fits a long
+func TestBigDecimalAddRescaled(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range [][3]string{
+		{"1", "0.000000000000000000001", "1.000000000000000000001"},
+		{"0.000000000000000000001", "1", "1.000000000000000000001"},
+		{"-1", "0.000000000000000000001", "-0.999999999999999999999"},
+	} {
+		if got := d(c[0]).Add(d(c[1])).String(); got != c[2] {
+			t.Errorf("%s + %s mismatch: %v", c[0], c[1], got)
+		}
+	}
+}
+
+// testing Divide of compacts when the divisor is rescaled
+func TestBigDecimalDivideRescaled(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	for _, c := range []struct {
+		a, b  string
+		scale types.Int
+		want  string
+	}{
+		{"1", "3", 5, "0.33333"},
+		{"1.00000", "3", 2, "0.33"},
+		{"10.000000", "0.3", 2, "33.33"},
+		{"-2.5000", "0.7", 1, "-3.6"},
+		{"1", "0.03", 2, "33.33"},
+		{"10", "0.0003", 3, "33333.333"},
+		{"1.5", "0.00007", 1, "21428.6"},
+		{"123.45", "6.7", 10, "18.4253731343"},
+	} {
+		if got := d(c.a).Divide(d(c.b), c.scale, bigger.ROUND_HALF_UP).String(); got != c.want {
+			t.Errorf("%s / %s mismatch: %v", c.a, c.b, got)
+		}
+	}
+}
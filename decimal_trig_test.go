@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing the trigonometric and hyperbolic functions against known values and
+// their own results at a higher precision
+func TestBigDecimalTrig(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	d20 := bigger.NewMathContext(20, bigger.ROUND_HALF_EVEN)
+	for _, c := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"pi", bigger.Pi(bigger.DECIMAL128).String(), "3.141592653589793238462643383279503"},
+		{"e", bigger.E(bigger.DECIMAL128).String(), "2.718281828459045235360287471352662"},
+		{"ln 2", bigger.Ln2(bigger.DECIMAL128).String(), "0.6931471805599453094172321214581766"},
+		{"sqrt 2", bigger.Sqrt2(bigger.DECIMAL128).String(), "1.414213562373095048801688724209698"},
+		{"sin 1", d("1").Sin(bigger.DECIMAL128).String(), "0.8414709848078965066525023216302990"},
+		{"cos 1", d("1").Cos(bigger.DECIMAL128).String(), "0.5403023058681397174009366074429766"},
+		{"tan 1", d("1").Tan(bigger.DECIMAL128).String(), "1.557407724654902230506974807458360"},
+		{"sin 355", d("355").Sin(d20).String(), "-0.000030144353359488449214"},
+		{"sin 1E+50", d("1E+50").Sin(d20).String(), "-0.78967249342931008271"},
+		{"atan 1", d("1").Atan(d20).String(), "0.78539816339744830962"},
+		{"asin 0.5", d("0.5").Asin(d20).String(), "0.52359877559829887308"},
+		{"acos -1", d("-1").Acos(d20).String(), "3.1415926535897932385"},
+		{"atan2 0 -1", d("0").Atan2(d("-1"), d20).String(), "3.1415926535897932385"},
+		{"atan2 -1 -1", d("-1").Atan2(d("-1"), d20).String(), "-2.3561944901923449288"},
+		{"sinh 1", d("1").Sinh(d20).String(), "1.1752011936438014569"},
+		{"cosh 1", d("1").Cosh(d20).String(), "1.5430806348152437785"},
+		{"tanh 0.5", d("0.5").Tanh(d20).String(), "0.46211715726000975850"},
+		{"asinh 1", d("1").Asinh(d20).String(), "0.88137358701954302523"},
+		{"acosh 2", d("2").Acosh(d20).String(), "1.3169578969248167086"},
+		{"atanh 0.5", d("0.5").Atanh(d20).String(), "0.54930614433405484570"},
+	} {
+		if ratOf(c.got).Cmp(ratOf(c.want)) != 0 {
+			t.Errorf("%s mismatch: %v", c.name, c.got)
+		}
+	}
+
+	for _, c := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"sin 0", d("0.00").Sin(bigger.UNLIMITED).String(), "0"},
+		{"cos 0", d("0").Cos(bigger.UNLIMITED).String(), "1"},
+		{"acos 1", d("1").Acos(bigger.UNLIMITED).String(), "0"},
+		{"acosh 1", d("1.0").Acosh(bigger.UNLIMITED).String(), "0"},
+		{"atan2 0 2", d("0").Atan2(d("2"), bigger.UNLIMITED).String(), "0"},
+		{"tanh 100 down", d("100").Tanh(bigger.NewMathContext(5, bigger.ROUND_DOWN)).String(), "0.99999"},
+		{"tanh -100 up", d("-100").Tanh(bigger.NewMathContext(5, bigger.ROUND_UP)).String(), "-1.0000"},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s mismatch: %v", c.name, c.got)
+		}
+	}
+
+	// pi at a thousand digits ends in ...216420198
+	pi := bigger.Pi(bigger.NewMathContext(1000, bigger.ROUND_DOWN)).String()
+	if !strings.HasSuffix(pi, "216420198") {
+		t.Errorf("pi at 1000 digits mismatch: %v", pi)
+	}
+
+	r := rand.New(rand.NewSource(48))
+	for i := 0; i < 300; i++ {
+		s := randomDecimal(r)
+		b := d(s)
+		// near zero the functions are within 10^-50 of their first terms,
+		// which the reference rounds to
+		if b.Abs().CompareTo(d("1E-6")) < 0 {
+			continue
+		}
+		p, mode := 1+r.Intn(40), roundingModes[r.Intn(len(roundingModes))]
+		mc := bigger.NewMathContext(types.Int(p), mode)
+		high := bigger.NewMathContext(types.Int(p+50), bigger.ROUND_HALF_EVEN)
+		check := func(what string, got, want interface {
+			Precision() types.Int
+			String() string
+		}) {
+			if got.Precision() > types.Int(p) || ratOf(got.String()).Cmp(roundRat(ratOf(want.String()), p, mode)) != 0 {
+				t.Fatalf("%s of %s to %d digits with mode %d mismatch: %v", what, s, p, mode, got)
+			}
+		}
+		check("sin", b.Sin(mc), b.Sin(high))
+		check("cos", b.Cos(mc), b.Cos(high))
+		check("tan", b.Tan(mc), b.Tan(high))
+		check("atan", b.Atan(mc), b.Atan(high))
+		check("atan2 with -3.5", b.Atan2(d("-3.5"), mc), b.Atan2(d("-3.5"), high))
+		check("asinh", b.Asinh(mc), b.Asinh(high))
+		if b.Precision()-b.Scale() <= 3 {
+			check("sinh", b.Sinh(mc), b.Sinh(high))
+			check("cosh", b.Cosh(mc), b.Cosh(high))
+		}
+		// likewise tanh beyond 40
+		if b.Abs().CompareTo(d("40")) < 0 {
+			check("tanh", b.Tanh(mc), b.Tanh(high))
+		}
+		if b.Abs().CompareTo(d("1")) < 0 {
+			check("asin", b.Asin(mc), b.Asin(high))
+			check("acos", b.Acos(mc), b.Acos(high))
+			check("atanh", b.Atanh(mc), b.Atanh(high))
+		}
+		y := b.Abs().Add(d("1"))
+		check("acosh", y.Acosh(mc), y.Acosh(high))
+	}
+}
+
+// seriesPrec is the precision of the series references, far past the 50
+// digits they are rounded to
+const seriesPrec = 1200
+
+// seriesAsinh returns asinh x for |x| < 1/10 from its series, the sum of
+// (-1)^n (2n)! / (4^n n!^2 (2n+1)) x^(2n+1).
+func seriesAsinh(x *big.Float) *big.Float {
+	sum := new(big.Float).SetPrec(seriesPrec)
+	c := new(big.Float).SetPrec(seriesPrec).Set(x)
+	x2 := new(big.Float).SetPrec(seriesPrec).Mul(x, x)
+	for n := int64(0); c.Sign() != 0 && c.MantExp(nil) > sum.MantExp(nil)-seriesPrec; n++ {
+		term := new(big.Float).SetPrec(seriesPrec).Quo(c, big.NewFloat(float64(2*n+1)))
+		if n%2 == 1 {
+			term.Neg(term)
+		}
+		sum.Add(sum, term)
+		c.Mul(c, x2).Mul(c, big.NewFloat(float64(2*n+1))).Quo(c, big.NewFloat(float64(2*n+2)))
+	}
+	return sum
+}
+
+// seriesAcosh returns acosh(1 + e) for 0 < e < 1/10 from its series,
+// sqrt(2e) times the sum of (-1)^n (2n)! / (8^n n!^2 (2n+1)) e^n.
+func seriesAcosh(e *big.Float) *big.Float {
+	sum := new(big.Float).SetPrec(seriesPrec)
+	c := new(big.Float).SetPrec(seriesPrec).SetInt64(1)
+	for n := int64(0); c.Sign() != 0 && c.MantExp(nil) > -seriesPrec; n++ {
+		term := new(big.Float).SetPrec(seriesPrec).Quo(c, big.NewFloat(float64(2*n+1)))
+		if n%2 == 1 {
+			term.Neg(term)
+		}
+		sum.Add(sum, term)
+		c.Mul(c, e).Mul(c, big.NewFloat(float64(2*n+1))).Quo(c, big.NewFloat(float64(4*n+4)))
+	}
+	root := new(big.Float).SetPrec(seriesPrec).Mul(e, big.NewFloat(2))
+	return sum.Mul(sum, root.Sqrt(root))
+}
+
+// testing Asinh and Acosh near their zeros against their series, where
+// sqrt(b^2 + 1) and sqrt(b^2 - 1) take square roots of sparse integers
+func TestBigDecimalAsinhAcoshSmall(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	d40 := bigger.NewMathContext(40, bigger.ROUND_HALF_EVEN)
+	if got := d("1E-30").Asinh(d40).String(); got != "1.000000000000000000000000000000000000000E-30" {
+		t.Errorf("asinh 1E-30 mismatch: %v", got)
+	}
+	if got := d("1.000000000000000000000000000001").Acosh(d40).String(); got != "1.414213562373095048801688724209580227439E-15" {
+		t.Errorf("acosh 1 + 1E-30 mismatch: %v", got)
+	}
+
+	r := rand.New(rand.NewSource(148))
+	for i := 0; i < 300; i++ {
+		s := fmt.Sprintf("%dE-%d", 1+r.Int63n(1e12), 13+r.Intn(100))
+		x, _, _ := big.ParseFloat(s, 10, seriesPrec, big.ToNearestEven)
+		p, mode := 1+r.Intn(50), roundingModes[r.Intn(len(roundingModes))]
+		mc := bigger.NewMathContext(types.Int(p), mode)
+		want, _ := seriesAsinh(x).Rat(nil)
+		if got := d(s).Asinh(mc); ratOf(got.String()).Cmp(roundRat(want, p, mode)) != 0 {
+			t.Fatalf("asinh of %s to %d digits with mode %d mismatch: %v", s, p, mode, got)
+		}
+		want, _ = seriesAcosh(x).Rat(nil)
+		if got := d(s).Add(d("1")).Acosh(mc); ratOf(got.String()).Cmp(roundRat(want, p, mode)) != 0 {
+			t.Fatalf("acosh of 1 + %s to %d digits with mode %d mismatch: %v", s, p, mode, got)
+		}
+	}
+}
+
+// taylor returns the sum of c[i] x^i
+func taylor(x *big.Rat, c ...*big.Rat) *big.Rat {
+	sum, pow := new(big.Rat), big.NewRat(1, 1)
+	for _, a := range c {
+		sum.Add(sum, new(big.Rat).Mul(a, pow))
+		pow = new(big.Rat).Mul(pow, x)
+	}
+	return sum
+}
+
+// testing arguments so small that the functions round like their first
+// terms, against the Taylor polynomials of degree five
+func TestBigDecimalTrigTiny(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	q, z := big.NewRat, new(big.Rat)
+	odd := []string{"1E-300", "-1E-300", "1.234567890123456789012345678901234567E-300", "2E-12", "-7.5E-11", "1E-10"}
+	even := append([]string{"1E-8000", "-1E-20000"}, odd...)
+	for _, c := range []struct {
+		name string
+		args []string
+		f    func(s string, p int, mode bigger.RoundingMode) string
+		c    []*big.Rat
+	}{
+		{"sin", odd, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Sin(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{z, q(1, 1), z, q(-1, 6), z, q(1, 120)}},
+		{"cos", even, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Cos(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{q(1, 1), z, q(-1, 2), z, q(1, 24)}},
+		{"tan", odd, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Tan(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{z, q(1, 1), z, q(1, 3), z, q(2, 15)}},
+		{"asin", odd, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Asin(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{z, q(1, 1), z, q(1, 6), z, q(3, 40)}},
+		{"atan", odd, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Atan(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{z, q(1, 1), z, q(-1, 3), z, q(1, 5)}},
+		{"sinh", odd, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Sinh(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{z, q(1, 1), z, q(1, 6), z, q(1, 120)}},
+		{"cosh", even, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Cosh(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{q(1, 1), z, q(1, 2), z, q(1, 24)}},
+		{"tanh", odd, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Tanh(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{z, q(1, 1), z, q(-1, 3), z, q(2, 15)}},
+		{"asinh", odd, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Asinh(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{z, q(1, 1), z, q(-1, 6), z, q(3, 40)}},
+		{"atanh", odd, func(s string, p int, mode bigger.RoundingMode) string {
+			return d(s).Atanh(bigger.NewMathContext(types.Int(p), mode)).String()
+		}, []*big.Rat{z, q(1, 1), z, q(1, 3), z, q(1, 5)}},
+	} {
+		for _, s := range c.args {
+			want := taylor(ratOf(s), c.c...)
+			for _, p := range []int{1, 3, 16} {
+				for _, mode := range roundingModes {
+					got := c.f(s, p, mode)
+					if d(got).Precision() > types.Int(p) || ratOf(got).Cmp(roundRat(want, p, mode)) != 0 {
+						t.Errorf("%s %s to %d digits with mode %d mismatch: %v", c.name, s, p, mode, got)
+					}
+				}
+			}
+		}
+	}
+
+	// the exponent alone is too large for roundRat
+	mc := bigger.NewMathContext(16, bigger.ROUND_DOWN)
+	for _, c := range []struct{ got, want string }{
+		{d("1E-20000").Sin(mc).String(), "9.999999999999999E-20001"},
+		{d("-1E-20000").Sinh(mc).String(), "-1.000000000000000E-20000"},
+		{d("1E-20000").Atan(mc).String(), "9.999999999999999E-20001"},
+		{d("1E-20000").Cos(mc).String(), "0.9999999999999999"},
+		{d("1E-20000").Cosh(mc).String(), "1.000000000000000"},
+	} {
+		if c.got != c.want {
+			t.Errorf("mismatch: %s, want %s", c.got, c.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing factorization and the arithmetic functions
+func TestFactor(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(34))
+	prime := func(bits int) *big.Int {
+		for {
+			p := new(big.Int).Rand(r, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+			if p.SetBit(p, bits-1, 1).ProbablyPrime(20) {
+				return p
+			}
+		}
+	}
+	// small factors, a rho sized factor, an ECM sized factor and a prime power
+	for _, bits := range [][]int{{3, 3, 9, 14}, {30, 33}, {44, 90}, {25, 25, 25}} {
+		n := big.NewInt(1)
+		for _, b := range bits {
+			n.Mul(n, prime(b))
+		}
+		factors, err := bigger.Factor(ctx, bigger.NewBigIntegerString(n.Neg(n).String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		product := bigger.BigIntegerValueOf(1)
+		for i, pf := range factors {
+			if !pf.Prime.IsProbablePrime(50) || i > 0 && factors[i-1].Prime.CompareTo(pf.Prime) >= 0 {
+				t.Errorf("factor %s of %v is not an ascending prime", pf.Prime, bits)
+			}
+			product = product.Multiply(pf.Prime.Pow(pf.Exponent))
+		}
+		if product.String() != new(big.Int).Abs(n).String() {
+			t.Errorf("factorization of %v mismatch", bits)
+		}
+	}
+
+	// the arithmetic functions against their definitions
+	for n := int64(1); n <= 300; n++ {
+		x := bigger.BigIntegerValueOf(types.Long(n))
+		var divisors []int64
+		phi, sigma2 := int64(0), int64(0)
+		for d := int64(1); d <= n; d++ {
+			if n%d == 0 {
+				divisors = append(divisors, d)
+				sigma2 += d * d
+			}
+			if new(big.Int).GCD(nil, nil, big.NewInt(d), big.NewInt(n)).Int64() == 1 {
+				phi++
+			}
+		}
+		if got, _ := bigger.EulerPhi(ctx, x); got.LongValue() != types.Long(phi) {
+			t.Errorf("eulerPhi %d mismatch", n)
+		}
+		if got, _ := bigger.SigmaK(ctx, x, 2); got.LongValue() != types.Long(sigma2) {
+			t.Errorf("sigma2 %d mismatch", n)
+		}
+		if got, _ := bigger.SigmaK(ctx, x, 0); got.LongValue() != types.Long(len(divisors)) {
+			t.Errorf("sigma0 %d mismatch", n)
+		}
+		got, _ := bigger.Divisors(ctx, x)
+		for i, d := range got {
+			if d.LongValue() != types.Long(divisors[i]) {
+				t.Errorf("divisors %d mismatch", n)
+			}
+		}
+		// mu(n) is the sum of mu(d) over the divisors d
+		mu, _ := bigger.Moebius(ctx, x)
+		sum := int64(0)
+		for _, d := range divisors {
+			m, _ := bigger.Moebius(ctx, bigger.BigIntegerValueOf(types.Long(d)))
+			sum += int64(m)
+		}
+		if sum != map[bool]int64{true: 1, false: 0}[n == 1] || (n == 12 && mu != 0) || (n == 30 && mu != -1) {
+			t.Errorf("moebius %d mismatch", n)
+		}
+	}
+
+	// a hard composite stops at the deadline
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	n := new(big.Int).Mul(prime(128), prime(128))
+	if _, err := bigger.Factor(ctx, bigger.NewBigIntegerString(n.String())); err != context.DeadlineExceeded {
+		t.Errorf("factor deadline mismatch: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+)
+
+// testing gcd, the bezout coefficients and lcm
+func TestGcd(t *testing.T) {
+	r := rand.New(rand.NewSource(36))
+	for _, size := range []int{0, 1, 2, 4, 9, 50, 300} {
+		for k := 0; k < 20; k++ {
+			a, b := randomBigInt(r, size), randomBigInt(r, 1+r.Intn(size+1))
+			if k%2 == 0 {
+				// a large common factor keeps Lehmer's steps going to the end
+				c := randomBigInt(r, size/2+1)
+				a.Mul(a, c)
+				b.Mul(b, c)
+			}
+			x, y := bigger.NewBigIntegerString(a.String()), bigger.NewBigIntegerString(b.String())
+			want := new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b))
+			if g := x.Gcd(y); g.String() != want.String() {
+				t.Errorf("gcd of %d ints mismatch", size)
+			}
+			e := x.ExtendedGcd(y)
+			if e[0].String() != want.String() || x.Multiply(e[1]).Add(y.Multiply(e[2])).CompareTo(e[0]) != 0 {
+				t.Errorf("extended gcd of %d ints mismatch", size)
+			}
+			if want.Sign() != 0 && y.Signum() != 0 && e[1].Abs().CompareTo(y.Abs().Divide(e[0])) > 0 {
+				t.Errorf("bezout coefficient of %d ints too large", size)
+			}
+			lcm := new(big.Int)
+			if want.Sign() != 0 {
+				lcm.Abs(lcm.Mul(a, b).Div(lcm, want))
+			}
+			if l := x.Lcm(y); l.String() != lcm.String() {
+				t.Errorf("lcm of %d ints mismatch", size)
+			}
+			if m := new(big.Int).Abs(b); m.Cmp(big.NewInt(1)) > 0 {
+				if inv := new(big.Int).ModInverse(a, m); inv != nil {
+					if got := x.ModInverse(y.Abs()); got.String() != inv.String() {
+						t.Errorf("modInverse of %d ints mismatch", size)
+					}
+				}
+			}
+		}
+	}
+
+	v := bigger.BigIntegerValueOf
+	if g := bigger.GcdMany(v(12), v(-18), v(30)); g.LongValue() != 6 {
+		t.Errorf("gcd of many mismatch: %v", g)
+	}
+	if l := bigger.LcmMany(v(4), v(-6), v(10)); l.LongValue() != 60 {
+		t.Errorf("lcm of many mismatch: %v", l)
+	}
+	if g, l := bigger.GcdMany(), bigger.LcmMany(); g.Signum() != 0 || l.LongValue() != 1 {
+		t.Errorf("empty gcd or lcm mismatch")
+	}
+	if e := v(0).ExtendedGcd(v(-5)); e[0].LongValue() != 5 || e[2].LongValue() != -1 {
+		t.Errorf("extended gcd with zero mismatch")
+	}
+}
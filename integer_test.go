@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/tool"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing types.Long.Abs beyond the 53 bits a float64 holds exactly
+func TestLongAbs(t *testing.T) {
+	for _, c := range [][2]types.Long{
+		{-9007199254740993, 9007199254740993},
+		{9007199254740993, 9007199254740993},
+		{-4611686018427387905, 4611686018427387905},
+		{-9223372036854775807, 9223372036854775807},
+		{0, 0},
+	} {
+		if got := c[0].Abs(); got != c[1] {
+			t.Errorf("|%d| mismatch: %d", c[0], got)
+		}
+	}
+}
+
+// testing tool.Copy and tool.CopyRange, which pad with zeros like Arrays.copyOf
+func TestToolCopy(t *testing.T) {
+	a := []types.Int{1, 2, 3}
+	for _, c := range []struct {
+		name string
+		got  []types.Int
+		want []types.Int
+	}{
+		{"copy longer", tool.Copy(a, 5), []types.Int{1, 2, 3, 0, 0}},
+		{"copy shorter", tool.Copy(a, 2), []types.Int{1, 2}},
+		{"copy same", tool.Copy(a, 3), []types.Int{1, 2, 3}},
+		{"range inside", tool.CopyRange(a, 1, 3), []types.Int{2, 3}},
+		{"range past the end", tool.CopyRange(a, 1, 5), []types.Int{2, 3, 0, 0}},
+		{"range from the end", tool.CopyRange(a, 3, 4), []types.Int{0}},
+	} {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s mismatch: %v", c.name, c.got)
+		}
+	}
+}
+
+// testing tool.Arraycopy and tool.CopyRangePosLen on overlapping ranges, which
+// copy as if through a temporary array like System.arraycopy
+func TestToolArraycopy(t *testing.T) {
+	a := []types.Int{1, 2, 3, 4, 5}
+	tool.Arraycopy(a, 0, a, 1, 4)
+	if want := []types.Int{1, 1, 2, 3, 4}; !reflect.DeepEqual(a, want) {
+		t.Errorf("Arraycopy right mismatch: %v", a)
+	}
+	a = []types.Int{1, 2, 3, 4, 5}
+	tool.Arraycopy(a, 1, a, 0, 4)
+	if want := []types.Int{2, 3, 4, 5, 5}; !reflect.DeepEqual(a, want) {
+		t.Errorf("Arraycopy left mismatch: %v", a)
+	}
+	a = []types.Int{1, 2, 3, 4, 5}
+	tool.CopyRangePosLen(a, 0, a, 2, 3)
+	if want := []types.Int{1, 2, 1, 2, 3}; !reflect.DeepEqual(a, want) {
+		t.Errorf("CopyRangePosLen mismatch: %v", a)
+	}
+}
+
+// testing BitLength, bigger.bigInteger vs bigInt
+func TestBigIntegerBitLength(t *testing.T) {
+	for _, s := range []string{"1", "255", "256", "-1", "-255", "-256", "-257", "1099511627776", "-4294967296", "18446744073709551616"} {
+		want, _ := new(big.Int).SetString(s, 10)
+		if want.Sign() < 0 {
+			want.Not(want)
+		}
+		if got := bigger.NewBigIntegerString(s).BitLength(); int(got) != want.BitLen() {
+			t.Errorf("bitLength of %s mismatch: %d", s, got)
+		}
+	}
+}
+
+// testing ZERO as an operand and a result
+func TestBigIntegerZero(t *testing.T) {
+	x := bigger.NewBigIntegerString("123456789012345678901234567890")
+	got := strings.Join([]string{
+		bigger.ZERO.String(), fmt.Sprint(bigger.ZERO.BitLength()), bigger.ZERO.Add(x).String(), x.Add(bigger.ZERO).String(),
+		bigger.ZERO.Multiply(x).String(), x.Multiply(bigger.ZERO).String(), x.Subtract(x).String(), bigger.ZERO.Subtract(x).String(),
+		bigger.ZERO.Pow(3).String(), bigger.ZERO.Sqrt().String(), bigger.ZERO.StringRadix(2), bigger.ZERO.Divide(x).String(),
+		fmt.Sprint(bigger.ZERO.CompareTo(x)), fmt.Sprint(bigger.ZERO.LongValue()),
+	}, " ")
+	if want := "0 0 123456789012345678901234567890 123456789012345678901234567890 0 0 0 -123456789012345678901234567890 0 0 0 0 -1 0"; got != want {
+		t.Errorf("ZERO mismatch: %v", got)
+	}
+}
+
+// testing Pow, which splits off the powers of two by the lowest set bit of
+// each base
+func TestBigIntegerPowLowestSetBit(t *testing.T) {
+	for _, c := range [][2]int64{{4, 3}, {3, 3}, {12, 5}, {6, 7}, {5, 4}, {1 << 20, 3}, {-24, 3}} {
+		got := bigger.BigIntegerValueOf(types.Long(c[0])).Pow(types.Int(c[1])).String()
+		if want := new(big.Int).Exp(big.NewInt(c[0]), big.NewInt(c[1]), nil).String(); got != want {
+			t.Errorf("%d^%d mismatch: %v", c[0], c[1], got)
+		}
+	}
+}
+
+// testing Pow, a result of more than MAX_INT32 bits panics
+func TestBigIntegerPowOverflow(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("(2^40)^(2^27) did not panic")
+		}
+	}()
+	bigger.BigIntegerValueOf(1 << 40).Pow(1 << 27)
+}
+
+// testing squares of one int values, bigger.bigInteger vs bigInt
+func TestBigIntegerSquareOneInt(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 200; i++ {
+		v := types.Long(r.Uint32())
+		x := bigger.BigIntegerValueOf(v)
+		if got, want := x.Multiply(x).String(), new(big.Int).Mul(big.NewInt(int64(v)), big.NewInt(int64(v))).String(); got != want {
+			t.Fatalf("%d^2 mismatch: %v", v, got)
+		}
+	}
+}
+
+// testing DivideAndRemainder on operands of both signs, bigger.bigInteger vs
+// bigInt
+func TestBigIntegerDivideSigns(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for _, n := range []int{1, 2, 3, 5, 20} {
+		for i := 0; i < 50; i++ {
+			a, b := testRandomInt(r, n), testRandomInt(r, 1+r.Intn(n))
+			if b.Sign() == 0 {
+				continue
+			}
+			qr := bigger.NewBigIntegerString(a.String()).DivideAndRemainder(bigger.NewBigIntegerString(b.String()))
+			q, m := new(big.Int).QuoRem(a, b, new(big.Int))
+			if qr[0].String() != q.String() || qr[1].String() != m.String() {
+				t.Fatalf("%v / %v mismatch: %v %v", a, b, qr[0], qr[1])
+			}
+		}
+	}
+}
+
+// testRandomInt returns a random value of up to ints 32 bit words and random sign
+func testRandomInt(r *rand.Rand, ints int) *big.Int {
+	x := new(big.Int).Rand(r, new(big.Int).Lsh(big.NewInt(1), uint(32*ints)))
+	if r.Intn(2) == 0 {
+		x.Neg(x)
+	}
+	return x
+}
+
+// testing Sqrt of multi int values, bigger.bigInteger vs bigInt
+func TestBigIntegerSqrt(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for _, n := range []int{2, 3, 4, 5} {
+		for i := 0; i < 50; i++ {
+			a := new(big.Int).Abs(testRandomInt(r, n))
+			if got, want := bigger.NewBigIntegerString(a.String()).Sqrt().String(), new(big.Int).Sqrt(a).String(); got != want {
+				t.Fatalf("sqrt of %v mismatch: %v", a, got)
+			}
+		}
+	}
+}
+
+// testing DivideAndRemainder on operands with trailing zero ints, which are
+// shifted out before the Knuth division
+func TestBigIntegerDivideTrailingZeros(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 100; i++ {
+		a := new(big.Int).Lsh(testRandomInt(r, 4+r.Intn(6)), uint(96+r.Intn(160)))
+		b := new(big.Int).Lsh(testRandomInt(r, 2+r.Intn(3)), uint(96+r.Intn(160)))
+		if b.Sign() == 0 {
+			continue
+		}
+		qr := bigger.NewBigIntegerString(a.String()).DivideAndRemainder(bigger.NewBigIntegerString(b.String()))
+		q, m := new(big.Int).QuoRem(a, b, new(big.Int))
+		if qr[0].String() != q.String() || qr[1].String() != m.String() {
+			t.Fatalf("%v / %v mismatch: %v %v", a, b, qr[0], qr[1])
+		}
+	}
+}
+
+// testing Pow of multi int values, compared without converting the results
+// to strings
+func TestBigIntegerPow(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 100; i++ {
+		a := new(big.Int).Lsh(testRandomInt(r, 1+r.Intn(100)), uint(r.Intn(100)))
+		n := r.Intn(8)
+		got := bigger.NewBigIntegerString(a.String()).Pow(types.Int(n))
+		want := bigger.NewBigIntegerString(new(big.Int).Exp(a, big.NewInt(int64(n)), nil).String())
+		if got.CompareTo(want) != 0 {
+			t.Fatalf("%v^%d mismatch", a, n)
+		}
+	}
+}
+
+// testing String and StringRadix of values past the Schoenhage conversion
+// threshold, bigger.bigInteger vs bigInt
+func TestBigIntegerStringLarge(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for _, n := range []int{20, 21, 50, 90} {
+		for i := 0; i < 10; i++ {
+			a := testRandomInt(r, n)
+			x := bigger.NewBigIntegerString(a.String())
+			for _, radix := range []int{10, 16, 2} {
+				if got := x.StringRadix(types.Int(radix)); got != a.Text(radix) {
+					t.Fatalf("%d ints in radix %d mismatch: %v", n, radix, got)
+				}
+			}
+		}
+	}
+}
+
+// testing DivideAndRemainder past the Burnikel-Ziegler threshold,
+// bigger.bigInteger vs bigInt
+func TestBigIntegerDivideLarge(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	for _, c := range [][2]int{{160, 81}, {200, 100}, {250, 120}, {400, 200}, {400, 81}} {
+		for i := 0; i < 5; i++ {
+			a, b := testRandomInt(r, c[0]), testRandomInt(r, c[1])
+			qr := bigger.NewBigIntegerString(a.String()).DivideAndRemainder(bigger.NewBigIntegerString(b.String()))
+			q, m := new(big.Int).QuoRem(a, b, new(big.Int))
+			if qr[0].String() != q.String() || qr[1].String() != m.String() {
+				t.Fatalf("%d / %d ints mismatch", c[0], c[1])
+			}
+		}
+	}
+}
+
+// testing Sqrt of all-ones values past the Burnikel-Ziegler threshold, whose
+// Newton steps divide by an estimate held at an offset
+func TestBigIntegerSqrtLarge(t *testing.T) {
+	for _, bits := range []int{5000, 6000, 7573, 8000, 9075} {
+		a := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bits)), big.NewInt(1))
+		if got := bigger.NewBigIntegerString(a.String()).Sqrt().String(); got != new(big.Int).Sqrt(a).String() {
+			t.Fatalf("sqrt of 2^%d - 1 mismatch", bits)
+		}
+	}
+}
+
+// testing NEGATIVE_ONE and the powers of negative bases that use it
+func TestBigIntegerNegativeOne(t *testing.T) {
+	if bigger.NEGATIVE_ONE == nil || bigger.NEGATIVE_ONE.String() != "-1" {
+		t.Fatalf("NEGATIVE_ONE mismatch: %v", bigger.NEGATIVE_ONE)
+	}
+	if got := bigger.BigIntegerValueOf(-2).Pow(5).String(); got != "-32" {
+		t.Errorf("(-2)^5 mismatch: %v", got)
+	}
+}
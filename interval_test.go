@@ -0,0 +1,130 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing interval arithmetic bounds against math/big
+func TestInterval(t *testing.T) {
+	d := bigger.NewBigDecimalString
+	r := rand.New(rand.NewSource(45))
+	// tight reports whether lo <= min and max <= hi, each within 10^-scale
+	tight := func(lo, hi string, min, max *big.Rat, scale int) bool {
+		ulp := ratOf("1E" + big.NewInt(int64(-scale)).String())
+		l, h := ratOf(lo), ratOf(hi)
+		return l.Cmp(min) <= 0 && new(big.Rat).Sub(min, l).Cmp(ulp) < 0 &&
+			max.Cmp(h) <= 0 && new(big.Rat).Sub(h, max).Cmp(ulp) < 0
+	}
+	hull := func(xs ...*big.Rat) (*big.Rat, *big.Rat) {
+		min, max := xs[0], xs[0]
+		for _, x := range xs[1:] {
+			if x.Cmp(min) < 0 {
+				min = x
+			}
+			if x.Cmp(max) > 0 {
+				max = x
+			}
+		}
+		return min, max
+	}
+	for i := 0; i < 1000; i++ {
+		a1, a2, b1, b2 := randomDecimal(r), randomDecimal(r), randomDecimal(r), randomDecimal(r)
+		if ratOf(a1).Cmp(ratOf(a2)) > 0 {
+			a1, a2 = a2, a1
+		}
+		if ratOf(b1).Cmp(ratOf(b2)) > 0 {
+			b1, b2 = b2, b1
+		}
+		a, b := bigger.NewInterval(d(a1), d(a2)), bigger.NewInterval(d(b1), d(b2))
+		al, ah, bl, bh := ratOf(a1), ratOf(a2), ratOf(b1), ratOf(b2)
+		scale := r.Intn(60) - 20
+		mul := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Mul(x, y) }
+
+		if s := a.Add(b, types.Int(scale)); !tight(s.Lower().String(), s.Upper().String(), new(big.Rat).Add(al, bl), new(big.Rat).Add(ah, bh), scale) {
+			t.Fatalf("%v + %v at scale %d mismatch: %v", a, b, scale, s)
+		}
+		if s := a.Subtract(b, types.Int(scale)); !tight(s.Lower().String(), s.Upper().String(), new(big.Rat).Sub(al, bh), new(big.Rat).Sub(ah, bl), scale) {
+			t.Fatalf("%v - %v at scale %d mismatch: %v", a, b, scale, s)
+		}
+		min, max := hull(mul(al, bl), mul(al, bh), mul(ah, bl), mul(ah, bh))
+		if s := a.Multiply(b, types.Int(scale)); !tight(s.Lower().String(), s.Upper().String(), min, max, scale) {
+			t.Fatalf("%v * %v at scale %d mismatch: %v", a, b, scale, s)
+		}
+		if !b.ContainsZero() {
+			q := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Quo(x, y) }
+			min, max := hull(q(al, bl), q(al, bh), q(ah, bl), q(ah, bh))
+			if s := a.Divide(b, types.Int(scale)); !tight(s.Lower().String(), s.Upper().String(), min, max, scale) {
+				t.Fatalf("%v / %v at scale %d mismatch: %v", a, b, scale, s)
+			}
+		}
+		if a.Lower().Signum() >= 0 {
+			s := a.Sqrt(types.Int(scale))
+			lo, hi := ratOf(s.Lower().String()), ratOf(s.Upper().String())
+			ulp := ratOf("1E" + big.NewInt(int64(-scale)).String())
+			nlo, nhi := new(big.Rat).Add(lo, ulp), new(big.Rat).Sub(hi, ulp)
+			if mul(lo, lo).Cmp(al) > 0 || mul(nlo, nlo).Cmp(al) <= 0 || mul(hi, hi).Cmp(ah) < 0 || nhi.Sign() >= 0 && mul(nhi, nhi).Cmp(ah) >= 0 {
+				t.Fatalf("sqrt %v at scale %d mismatch: %v", a, scale, s)
+			}
+		}
+		if mid := a.Midpoint(); !a.Contains(mid) || ratOf(a.Width().String()).Cmp(new(big.Rat).Sub(ah, al)) != 0 ||
+			new(big.Rat).Add(ratOf(mid.String()), ratOf(mid.String())).Cmp(new(big.Rat).Add(al, ah)) != 0 {
+			t.Fatalf("midpoint or width of %v mismatch", a)
+		}
+		if !a.Add(b, types.Int(scale)).ContainsInterval(a.Add(b, types.Int(scale+5))) {
+			t.Fatalf("%v + %v at scale %d does not contain the finer sum", a, b, scale)
+		}
+	}
+
+	for _, c := range []struct{ x, y, z, w, want string }{
+		{"1", "2", "-3", "0.5", "[-inf, -0.333][2.000, +inf]"},
+		{"-2", "-1", "-3", "0.5", "[-inf, -2.000][0.333, +inf]"},
+		{"-3", "0.5", "-3", "0.5", "[-inf, +inf]"},
+		{"1", "2", "0", "2", "[0.500, +inf]"},
+		{"1", "2", "-2", "0", "[-inf, -0.500]"},
+		{"1", "2", "0", "0", ""},
+		{"1", "2", "3", "7", "[0.142, 0.667]"},
+	} {
+		s := ""
+		for _, p := range bigger.NewInterval(d(c.x), d(c.y)).DivideExtended(bigger.NewInterval(d(c.z), d(c.w)), 3) {
+			s += p.String()
+		}
+		if s != c.want {
+			t.Errorf("[%s, %s] / [%s, %s] mismatch: %s", c.x, c.y, c.z, c.w, s)
+		}
+	}
+	if got := bigger.NewIntervalPoint(d("2")).Sqrt(10).String(); got != "[1.4142135623, 1.4142135624]" {
+		t.Errorf("sqrt 2 mismatch: %v", got)
+	}
+	if got := bigger.NewIntervalPoint(d("0.0144")).Sqrt(4).String(); got != "[0.1200, 0.1200]" {
+		t.Errorf("sqrt 0.0144 mismatch: %v", got)
+	}
+}
+
+// testing that Sqrt encloses the root of sparse powers of two 2^e + 2^f,
+// whose top ints rounded the integer root low
+func TestIntervalSqrtSparse(t *testing.T) {
+	one := big.NewInt(1)
+	a := new(big.Int).Add(new(big.Int).Lsh(one, 400), new(big.Int).Lsh(one, 336))
+	want := "[1606938044258990275585518235307042725844924647723700460716047, 1606938044258990275585518235307042725844924647723700460716048]"
+	if got := bigger.NewIntervalPoint(bigger.NewBigDecimalString(a.String())).Sqrt(0).String(); got != want {
+		t.Errorf("sqrt 2^400 + 2^336 mismatch: %v", got)
+	}
+	for e := uint(64); e < 800; e += 45 {
+		for f := uint(0); f < e; f += 27 {
+			x := new(big.Int).Add(new(big.Int).Lsh(one, e), new(big.Int).Lsh(one, f))
+			for _, scale := range []int{0, 5} {
+				s := bigger.NewIntervalPoint(bigger.NewBigDecimalString(x.String())).Sqrt(types.Int(scale))
+				lo, hi := ratOf(s.Lower().String()), ratOf(s.Upper().String())
+				xr := new(big.Rat).SetInt(x)
+				if new(big.Rat).Mul(lo, lo).Cmp(xr) > 0 || new(big.Rat).Mul(hi, hi).Cmp(xr) < 0 {
+					t.Fatalf("sqrt 2^%d + 2^%d at scale %d mismatch: %v", e, f, scale, s)
+				}
+			}
+		}
+	}
+}
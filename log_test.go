@@ -0,0 +1,59 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing integer logarithms and digit counts, bigger.bigInteger vs bigInt
+func TestLog(t *testing.T) {
+	r := rand.New(rand.NewSource(31))
+	for _, size := range []int{1, 2, 7, 60, 300} {
+		for k := 0; k < 4; k++ {
+			a := randomBigInt(r, size)
+			x := bigger.NewBigIntegerString(a.String())
+			for _, radix := range []int{2, 3, 8, 10, 16, 36} {
+				if got := x.DigitCount(types.Int(radix)); int(got) != len(new(big.Int).Abs(a).Text(radix)) {
+					t.Errorf("digit count radix %d of %d ints mismatch", radix, size)
+				}
+			}
+			x = x.Abs()
+			if got := x.Log2Floor(); int(got) != new(big.Int).Abs(a).BitLen()-1 {
+				t.Errorf("log2 of %d ints mismatch", size)
+			}
+			if got := x.Log10Floor(); int(got) != len(new(big.Int).Abs(a).String())-1 {
+				t.Errorf("log10 of %d ints mismatch", size)
+			}
+			base := bigger.BigIntegerValueOf(12345)
+			e := x.ILog(base)
+			if base.Pow(e).CompareTo(x) > 0 || base.Pow(e+1).CompareTo(x) <= 0 {
+				t.Errorf("ilog of %d ints mismatch", size)
+			}
+		}
+	}
+	for _, s := range []string{"9", "10", "99999999999999999999", "100000000000000000000"} {
+		x := bigger.NewBigIntegerString(s)
+		if int(x.DigitCount(10)) != len(s) || int(x.Log10Floor()) != len(s)-1 {
+			t.Errorf("digit count of %s mismatch", s)
+		}
+	}
+	if bigger.BigIntegerValueOf(0).DigitCount(10) != 1 {
+		t.Errorf("digit count of 0 mismatch")
+	}
+	// negative powers of two have one bit less in two's complement
+	for _, c := range []struct {
+		x     int64
+		radix types.Int
+		want  types.Int
+	}{
+		{-8, 2, 4}, {-1, 2, 1}, {-1024, 2, 11}, {-16, 16, 2}, {-4096, 8, 5}, {-1000, 10, 4},
+	} {
+		if got := bigger.BigIntegerValueOf(types.Long(c.x)).DigitCount(c.radix); got != c.want {
+			t.Errorf("digit count radix %d of %d mismatch: %d", c.radix, c.x, got)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+)
+
+// testing modular arithmetic and primality, bigger.bigInteger vs bigInt
+func TestModArithmetic(t *testing.T) {
+	r := rand.New(rand.NewSource(33))
+	for _, size := range []int{1, 2, 5, 30, 100} {
+		for k := 0; k < 5; k++ {
+			a, m, e := randomBigInt(r, size), new(big.Int).Abs(randomBigInt(r, size/2+1)), new(big.Int).Abs(randomBigInt(r, 3))
+			m.Add(m, big.NewInt(2))
+			x, y, z := bigger.NewBigIntegerString(a.String()), bigger.NewBigIntegerString(m.String()), bigger.NewBigIntegerString(e.String())
+			if got := x.Mod(y).String(); got != new(big.Int).Mod(a, m).String() {
+				t.Errorf("mod %d ints mismatch", size)
+			}
+			if got := x.Remainder(y).String(); got != new(big.Int).Rem(a, m).String() {
+				t.Errorf("remainder %d ints mismatch", size)
+			}
+			if got := x.Gcd(y).String(); got != new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), m).String() {
+				t.Errorf("gcd %d ints mismatch", size)
+			}
+			if got := x.ModPow(z, y).String(); got != new(big.Int).Exp(a, e, m).String() {
+				t.Errorf("modPow %d ints mismatch", size)
+			}
+			if inv := new(big.Int).ModInverse(a, m); inv != nil {
+				if got := x.ModInverse(y).String(); got != inv.String() {
+					t.Errorf("modInverse %d ints mismatch", size)
+				}
+				if got := x.ModPow(z.Negate(), y).String(); got != new(big.Int).Exp(inv, e, m).String() {
+					t.Errorf("modPow negative exponent %d ints mismatch", size)
+				}
+			}
+			if size > 30 {
+				continue
+			}
+			p := new(big.Int).Abs(a)
+			for !p.ProbablyPrime(20) {
+				p.Add(p, big.NewInt(1))
+			}
+			if !bigger.NewBigIntegerString(p.String()).IsProbablePrime(100) {
+				t.Errorf("isProbablePrime of a %d ints prime mismatch", size)
+			}
+			if q := new(big.Int).Mul(p, p); bigger.NewBigIntegerString(q.String()).IsProbablePrime(100) {
+				t.Errorf("isProbablePrime of a %d ints square mismatch", size)
+			}
+		}
+	}
+	// strong pseudoprimes to several bases and a Carmichael number
+	for _, s := range []string{"2047", "561", "3215031751", "3825123056546413051", "3317044064679887385961981"} {
+		if bigger.NewBigIntegerString(s).IsProbablePrime(100) {
+			t.Errorf("isProbablePrime of %s mismatch", s)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+)
+
+// testing reduction contexts, Montgomery for odd and Barrett for even moduli, bigger.bigInteger vs bigInt
+func TestModContext(t *testing.T) {
+	r := rand.New(rand.NewSource(39))
+	for _, size := range []int{1, 2, 5, 30, 300} {
+		for k := 0; k < 6; k++ {
+			m := new(big.Int).Abs(randomBigInt(r, size))
+			m.SetBit(m, 0, uint(k&1)).SetBit(m, 32*size-1, 1)
+			c := bigger.NewModContext(bigger.NewBigIntegerString(m.String()))
+			a, b, e := randomBigInt(r, 2*size+k%2), randomBigInt(r, size), new(big.Int).Abs(randomBigInt(r, 2))
+			x, y, z := bigger.NewBigIntegerString(a.String()), bigger.NewBigIntegerString(b.String()), bigger.NewBigIntegerString(e.String())
+			if got := c.Reduce(x).String(); got != new(big.Int).Mod(a, m).String() {
+				t.Errorf("reduce %d ints mismatch", size)
+			}
+			if got := c.MulMod(x, y).String(); got != new(big.Int).Mod(new(big.Int).Mul(a, b), m).String() {
+				t.Errorf("mulMod %d ints mismatch", size)
+			}
+			if got := c.SqrMod(y).String(); got != new(big.Int).Mod(new(big.Int).Mul(b, b), m).String() {
+				t.Errorf("sqrMod %d ints mismatch", size)
+			}
+			if got := c.ExpMod(x, z).String(); got != new(big.Int).Exp(new(big.Int).Mod(a, m), e, m).String() {
+				t.Errorf("expMod %d ints mismatch", size)
+			}
+			if inv := new(big.Int).ModInverse(a, m); inv != nil {
+				if got := c.Inverse(x).String(); got != inv.String() {
+					t.Errorf("inverse %d ints mismatch", size)
+				}
+			}
+		}
+	}
+}
+
+// testing ModPow through the context kept for the last modulus: in runs of
+// one modulus, alternating moduli, equal moduli of other bigIntegers and from
+// several goroutines
+func TestModPowCached(t *testing.T) {
+	r := rand.New(rand.NewSource(139))
+	var moduli []*big.Int
+	for _, size := range []int{1, 3, 8} {
+		for k := 0; k < 2; k++ {
+			m := new(big.Int).Abs(randomBigInt(r, size))
+			moduli = append(moduli, m.SetBit(m, 0, uint(k)).SetBit(m, 32*size-1, 1))
+		}
+	}
+	check := func(r *rand.Rand, m *big.Int) bool {
+		a, e := randomBigInt(r, 4), new(big.Int).Abs(randomBigInt(r, 2))
+		got := bigger.NewBigIntegerString(a.String()).ModPow(bigger.NewBigIntegerString(e.String()), bigger.NewBigIntegerString(m.String()))
+		return got.String() == new(big.Int).Exp(new(big.Int).Mod(a, m), e, m).String()
+	}
+	for i := 0; i < 200; i++ {
+		// a run of three powers to a modulus, then the next one
+		if m := moduli[i/3%len(moduli)]; !check(r, m) {
+			t.Fatalf("modPow mod %v mismatch", m)
+		}
+		if m := moduli[r.Intn(len(moduli))]; !check(r, m) {
+			t.Fatalf("modPow mod %v mismatch", m)
+		}
+	}
+
+	done := make(chan bool)
+	for g := 0; g < 4; g++ {
+		go func(g int) {
+			r := rand.New(rand.NewSource(int64(g)))
+			ok := true
+			for i := 0; i < 100; i++ {
+				ok = ok && check(r, moduli[(g+i/5)%len(moduli)])
+			}
+			done <- ok
+		}(g)
+	}
+	for g := 0; g < 4; g++ {
+		if !<-done {
+			t.Errorf("concurrent modPow mismatch")
+		}
+	}
+}
+
+func BenchmarkModContextExpMod(b *testing.B) {
+	r := rand.New(rand.NewSource(39))
+	m := new(big.Int).Abs(randomBigInt(r, 64))
+	m.SetBit(m, 0, 1)
+	c := bigger.NewModContext(bigger.NewBigIntegerString(m.String()))
+	x, e := bigger.NewBigIntegerString(randomBigInt(r, 64).String()), bigger.NewBigIntegerString(new(big.Int).Abs(randomBigInt(r, 64)).String())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.ExpMod(x, e)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+)
+
+func randomBigInt(r *rand.Rand, ints int) *big.Int {
+	x := new(big.Int).Rand(r, new(big.Int).Lsh(big.NewInt(1), uint(32*ints)))
+	if r.Intn(2) == 0 {
+		x.Neg(x)
+	}
+	return x
+}
+
+// testing NTT multiplication and squaring, bigger.bigInteger vs bigInt
+func TestNTTMultiply(t *testing.T) {
+	saved := bigger.GetThresholds()
+	defer bigger.SetThresholds(saved)
+	th := saved
+	th.FFTMultiply, th.FFTSquare = 4, 4
+	bigger.SetThresholds(th)
+
+	r := rand.New(rand.NewSource(26))
+	for _, n := range []int{4, 5, 17, 128, 300, 1000} {
+		a, b := randomBigInt(r, n), randomBigInt(r, n+r.Intn(n))
+		x, y := bigger.NewBigIntegerString(a.String()), bigger.NewBigIntegerString(b.String())
+		if got, want := x.Multiply(y).String(), new(big.Int).Mul(a, b).String(); got != want {
+			t.Errorf("multiply %d ints: got %s, want %s", n, got, want)
+		}
+		if got, want := x.Multiply(x).String(), new(big.Int).Mul(a, a).String(); got != want {
+			t.Errorf("square %d ints: got %s, want %s", n, got, want)
+		}
+		if got, want := x.Pow(7).String(), new(big.Int).Exp(a, big.NewInt(7), nil).String(); got != want {
+			t.Errorf("pow %d ints: got %s, want %s", n, got, want)
+		}
+	}
+}
+
+func BenchmarkBiggerIntegerMultiplyHuge(bb *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	a := bigger.NewBigIntegerString(randomBigInt(r, 20000).String())
+	b := bigger.NewBigIntegerString(randomBigInt(r, 20000).String())
+	bb.ResetTimer()
+	for i := 0; i < bb.N; i++ {
+		a.Multiply(b)
+	}
+}
+func BenchmarkBigintIntegerMultiplyHuge(bb *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	a, b := randomBigInt(r, 20000), randomBigInt(r, 20000)
+	c := new(big.Int)
+	bb.ResetTimer()
+	for i := 0; i < bb.N; i++ {
+		c.Mul(a, b)
+	}
+}
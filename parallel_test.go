@@ -0,0 +1,36 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+)
+
+// testing parallel evaluation, bigger.bigInteger vs bigInt
+func TestParallelArithmetic(t *testing.T) {
+	bigger.SetParallelism(4)
+	defer bigger.SetParallelism(1)
+	if bigger.Parallelism() != 4 {
+		t.Fatalf("Parallelism() = %d, want 4", bigger.Parallelism())
+	}
+
+	r := rand.New(rand.NewSource(27))
+	for _, n := range []int{600, 2500} {
+		a, b := randomBigInt(r, 2*n), randomBigInt(r, n)
+		x, y := bigger.NewBigIntegerString(a.String()), bigger.NewBigIntegerString(b.String())
+		if got, want := x.String(), a.String(); got != want {
+			t.Errorf("string %d ints mismatch", n)
+		}
+		if got, want := x.Multiply(y).String(), new(big.Int).Mul(a, b).String(); got != want {
+			t.Errorf("multiply %d ints mismatch", n)
+		}
+		if got, want := x.Multiply(x).String(), new(big.Int).Mul(a, a).String(); got != want {
+			t.Errorf("square %d ints mismatch", n)
+		}
+		if got, want := x.Divide(y).String(), new(big.Int).Quo(a, b).String(); got != want {
+			t.Errorf("divide %d ints mismatch", n)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// randomCoefficients returns degree+1 random coefficients of the given ints
+func randomCoefficients(r *rand.Rand, degree, ints int) []*big.Int {
+	c := make([]*big.Int, degree+1)
+	for i := range c {
+		c[i] = randomBigInt(r, ints)
+	}
+	return c
+}
+
+// testing polynomial arithmetic, bigger.polynomial vs schoolbook products of bigInt
+func TestPolynomial(t *testing.T) {
+	r := rand.New(rand.NewSource(40))
+	for _, size := range []int{1, 3, 20} {
+		for _, degree := range []int{0, 1, 7, 40} {
+			a, b, c := randomCoefficients(r, degree, size), randomCoefficients(r, degree/2+1, size), randomCoefficients(r, 2, 1)
+			ba, bb, bc := bigger.BigIntegers(), bigger.BigIntegers(), bigger.BigIntegers()
+			for _, x := range a {
+				ba = append(ba, bigger.NewBigIntegerString(x.String()))
+			}
+			for _, x := range b {
+				bb = append(bb, bigger.NewBigIntegerString(x.String()))
+			}
+			for _, x := range c {
+				bc = append(bc, bigger.NewBigIntegerString(x.String()))
+			}
+			p, q, g := bigger.NewPolynomial(ba...), bigger.NewPolynomial(bb...), bigger.NewPolynomial(bc...).PrimitivePart()
+
+			want := make([]*big.Int, len(a)+len(b)-1)
+			for i := range want {
+				want[i] = new(big.Int)
+			}
+			for i := range a {
+				for j := range b {
+					want[i+j].Add(want[i+j], new(big.Int).Mul(a[i], b[j]))
+				}
+			}
+			pq := p.Multiply(q)
+			for i, c := range want {
+				if pq.Coefficient(types.Int(i)).String() != c.String() {
+					t.Errorf("multiply of degree %d, %d ints mismatch", degree, size)
+					break
+				}
+			}
+			rem := bigger.NewPolynomial(bb[:len(bb)-1]...)
+			if d := pq.Add(rem).DivideAndRemainder(q); !d[0].Equals(p) || !d[1].Equals(rem) {
+				t.Errorf("divide of degree %d, %d ints mismatch", degree, size)
+			}
+			if d := pq.Subtract(q).PseudoDivideAndRemainder(p); !d[0].Multiply(p).Add(d[1]).Equals(pq.Subtract(q).Multiply(bigger.NewPolynomial(p.LeadingCoefficient().Pow(pq.Degree() - p.Degree() + 1)))) {
+				t.Errorf("pseudo divide of degree %d, %d ints mismatch", degree, size)
+			}
+
+			x := randomBigInt(r, 2)
+			v := new(big.Int)
+			for i := len(a) - 1; i >= 0; i-- {
+				v.Mul(v, x).Add(v, a[i])
+			}
+			if got := p.Evaluate(bigger.NewBigIntegerString(x.String())); got.String() != v.String() {
+				t.Errorf("evaluate of degree %d, %d ints mismatch", degree, size)
+			}
+			if dp := p.Derivative(); degree > 0 && dp.Coefficient(types.Int(degree-1)).String() != new(big.Int).Mul(a[degree], big.NewInt(int64(degree))).String() {
+				t.Errorf("derivative of degree %d, %d ints mismatch", degree, size)
+			}
+
+			// a common factor survives the gcd, over the integers and modulo a prime
+			if gcd := p.Multiply(g).Gcd(q.Multiply(g)); gcd.DivideAndRemainder(g)[1].Degree() >= 0 {
+				t.Errorf("gcd of degree %d, %d ints misses the common factor", degree, size)
+			}
+			m := bigger.NewBigIntegerString("1000000007")
+			if gm := g.Mod(m); gm.Degree() == 2 {
+				gcd := p.Multiply(g).GcdMod(q.Multiply(g), m)
+				if gcd.Degree() < 2 || gcd.DivideAndRemainderMod(gm, m)[1].Degree() >= 0 {
+					t.Errorf("gcd mod p of degree %d, %d ints misses the common factor", degree, size)
+				}
+			}
+		}
+	}
+}
+
+// testing integer root isolation
+func TestPolynomialIntegerRoots(t *testing.T) {
+	v := bigger.BigIntegerValueOf
+	root := bigger.NewBigIntegerString("-123456789012345678901234567890")
+	// (x - 3)^2 (x + 2) (2x - 1) (x^2 + 1) (x - root)
+	p := bigger.NewPolynomial(v(-3), v(1))
+	p = p.Multiply(p).Multiply(bigger.NewPolynomial(v(2), v(1))).Multiply(bigger.NewPolynomial(v(-1), v(2)))
+	p = p.Multiply(bigger.NewPolynomial(v(1), v(0), v(1))).Multiply(bigger.NewPolynomial(root.Negate(), v(1)))
+	roots := p.IntegerRoots()
+	if len(roots) != 3 || roots[0].CompareTo(root) != 0 || roots[1].LongValue() != -2 || roots[2].LongValue() != 3 {
+		t.Errorf("integer roots mismatch: %v", roots)
+	}
+	if s := bigger.NewPolynomial(v(5), v(-1), v(0), v(3)).String(); s != "3x^3 - x + 5" {
+		t.Errorf("string mismatch: %s", s)
+	}
+}
+
+// randomRats returns degree+1 random fractions with numerators of the given ints
+func randomRats(r *rand.Rand, degree, ints int) []*big.Rat {
+	c := make([]*big.Rat, degree+1)
+	for i := range c {
+		d := randomBigInt(r, 1)
+		c[i] = new(big.Rat).SetFrac(randomBigInt(r, ints), d.Abs(d).Add(d, big.NewInt(1)))
+	}
+	return c
+}
+
+// ratStrings returns the fractions of c as strings
+func ratStrings(c []*big.Rat) []string {
+	s := make([]string, len(c))
+	for i, x := range c {
+		s[i] = x.RatString()
+	}
+	return s
+}
+
+// ratDivide returns the quotient and remainder of the long division of a by d over big.Rat
+func ratDivide(a, d []*big.Rat) ([]*big.Rat, []*big.Rat) {
+	r := append([]*big.Rat(nil), a...)
+	m := len(d) - 1
+	if len(a) <= m {
+		return nil, r
+	}
+	q := make([]*big.Rat, len(a)-m)
+	for i := len(q) - 1; i >= 0; i-- {
+		q[i] = new(big.Rat).Quo(r[i+m], d[m])
+		for j := 0; j <= m; j++ {
+			r[i+j] = new(big.Rat).Sub(r[i+j], new(big.Rat).Mul(q[i], d[j]))
+		}
+	}
+	return q, r[:m]
+}
+
+// testing polynomials over the rationals against long division with big.Rat
+func TestRationalPolynomial(t *testing.T) {
+	rp := bigger.NewRationalPolynomialString
+	str := func(c []*big.Rat) string {
+		return rp(ratStrings(c)...).String()
+	}
+	r := rand.New(rand.NewSource(41))
+	for _, size := range []int{1, 3} {
+		for _, degree := range []int{0, 1, 5, 12} {
+			a, d := randomRats(r, degree+3, size), randomRats(r, degree, size)
+			pa, pd := rp(ratStrings(a)...), rp(ratStrings(d)...)
+			q, rem := ratDivide(a, d)
+			qr := pa.DivideAndRemainder(pd)
+			if qr[0].String() != str(q) || qr[1].String() != str(rem) {
+				t.Errorf("divide of degree %d, %d ints mismatch", degree, size)
+			}
+			if !qr[0].Multiply(pd).Add(qr[1]).Equals(pa) || !pa.Subtract(qr[1]).Subtract(qr[0].Multiply(pd)).Equals(rp()) {
+				t.Errorf("q d + r of degree %d, %d ints mismatch", degree, size)
+			}
+			// the gcd with a coprime e is d made monic
+			pe := rp(ratStrings(randomRats(r, degree+1, size))...)
+			if g := pa.Multiply(pd).Gcd(pe.Multiply(pd)); !g.Equals(pd.DivideAndRemainder(rp(d[degree].RatString()))[0]) {
+				t.Errorf("gcd of degree %d, %d ints mismatch", degree, size)
+			}
+
+			x := new(big.Rat).SetFrac(randomBigInt(r, 1), big.NewInt(7))
+			v := new(big.Rat)
+			for i := len(a) - 1; i >= 0; i-- {
+				v.Mul(v, x).Add(v, a[i])
+			}
+			n, dn := pa.Evaluate(bigger.NewBigIntegerString(x.Num().String()), bigger.NewBigIntegerString(x.Denom().String()))
+			if n.String() != v.Num().String() || dn.String() != v.Denom().String() {
+				t.Errorf("evaluate of degree %d, %d ints mismatch", degree, size)
+			}
+		}
+	}
+
+	// (x^2 + 1) / (2x + 1) = x/2 - 1/4 remainder 5/4
+	qr := rp("1", "0", "1").DivideAndRemainder(rp("1", "2"))
+	if qr[0].String() != "(2x - 1)/4" || qr[1].String() != "5/4" {
+		t.Errorf("divide mismatch: %s, %s", qr[0], qr[1])
+	}
+	if s := rp("1/2", "-2/3", "0", "5/6").Derivative().String(); s != "(15x^2 - 4)/6" {
+		t.Errorf("derivative mismatch: %s", s)
+	}
+	if m := rp("1/2", "3").Mod(bigger.BigIntegerValueOf(7)).String(); m != "3x + 4" {
+		t.Errorf("mod mismatch: %s", m)
+	}
+}
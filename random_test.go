@@ -0,0 +1,51 @@
+package main
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing random generation, bigger.bigInteger vs crypto/rand on the same seeded reader
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(37))
+	for _, size := range []int{1, 2, 5, 40} {
+		for k := 0; k < 20; k++ {
+			max := new(big.Int).Abs(randomBigInt(r, size))
+			max.Add(max, big.NewInt(1))
+			seed := r.Int63()
+			want, _ := crand.Int(rand.New(rand.NewSource(seed)), max)
+			lo := bigger.NewBigIntegerString(randomBigInt(r, size).String())
+			got, err := bigger.RandomInRange(lo, lo.Add(bigger.NewBigIntegerString(max.String())), rand.New(rand.NewSource(seed)))
+			if err != nil || got.Subtract(lo).String() != want.String() {
+				t.Errorf("random in range of %d ints mismatch: %v", size, err)
+			}
+		}
+	}
+
+	for _, n := range []int{0, 1, 7, 8, 9, 100} {
+		x, _ := bigger.RandomBits(types.Int(n), r)
+		if x.Signum() < 0 || int(x.BitLength()) > n {
+			t.Errorf("random of %d bits mismatch: %v", n, x)
+		}
+	}
+
+	// every one of the 49 multiples of 0.1 in [-1.25, 3.7) is hit, nothing else
+	lo, hi := bigger.NewBigDecimalString("-1.25"), bigger.NewBigDecimalString("3.7")
+	seen := map[string]bool{}
+	for k := 0; k < 2000; k++ {
+		d, err := bigger.RandomBigDecimal(lo, hi, 1, r)
+		v, ok := new(big.Rat).SetString(d.String())
+		if err != nil || !ok || v.Cmp(big.NewRat(-12, 10)) < 0 || v.Cmp(big.NewRat(36, 10)) > 0 {
+			t.Fatalf("random decimal %v out of range: %v", d, err)
+		}
+		seen[v.String()] = true
+	}
+	if len(seen) != 49 {
+		t.Errorf("random decimal hit %d values", len(seen))
+	}
+}
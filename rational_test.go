@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing rational arithmetic, bigger.bigRational vs bigRat
+func TestBigRational(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	for _, size := range []int{1, 2, 10} {
+		for k := 0; k < 20; k++ {
+			x := new(big.Rat).SetFrac(randomBigInt(r, size), new(big.Int).Add(new(big.Int).Abs(randomBigInt(r, size)), big.NewInt(1)))
+			y := new(big.Rat).SetFrac(randomBigInt(r, size), new(big.Int).Sub(randomBigInt(r, 1), big.NewInt(1<<32)))
+			bx, by := bigger.NewBigRationalString(x.RatString()), bigger.NewBigRationalString(y.RatString())
+			if got := bx.Add(by).String(); got != new(big.Rat).Add(x, y).RatString() {
+				t.Errorf("add %d ints mismatch", size)
+			}
+			if got := bx.Subtract(by).String(); got != new(big.Rat).Sub(x, y).RatString() {
+				t.Errorf("subtract %d ints mismatch", size)
+			}
+			if got := bx.Multiply(by).String(); got != new(big.Rat).Mul(x, y).RatString() {
+				t.Errorf("multiply %d ints mismatch", size)
+			}
+			if y.Sign() != 0 {
+				if got := bx.Divide(by).String(); got != new(big.Rat).Quo(x, y).RatString() {
+					t.Errorf("divide %d ints mismatch", size)
+				}
+			}
+			if bx.CompareTo(by) != types.Int(x.Cmp(y)) {
+				t.Errorf("compare %d ints mismatch", size)
+			}
+			floor := new(big.Int).Div(x.Num(), x.Denom())
+			if got := bx.Floor().String(); got != floor.String() {
+				t.Errorf("floor %d ints mismatch", size)
+			}
+			if got := bx.Ceil().String(); got != new(big.Int).Neg(new(big.Int).Div(new(big.Int).Neg(x.Num()), x.Denom())).String() {
+				t.Errorf("ceil %d ints mismatch", size)
+			}
+			pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)
+			down := new(big.Rat).SetFrac(new(big.Int).Quo(new(big.Int).Mul(x.Num(), pow), x.Denom()), pow)
+			if got := bigger.NewBigRationalBigDecimal(bx.BigDecimal(30, bigger.ROUND_DOWN)).String(); got != down.RatString() {
+				t.Errorf("to decimal %d ints mismatch", size)
+			}
+		}
+	}
+
+	for s, want := range map[string]string{"3/4": "3/4", "-1 1/2": "-3/2", "2 6/4": "7/2", "-6/8": "-3/4", "1.25": "5/4", "-2.5e-3": "-1/400", "1E+3": "1000"} {
+		if got := bigger.NewBigRationalString(s).String(); got != want {
+			t.Errorf("parse %s mismatch: %s", s, got)
+		}
+	}
+	third := bigger.BigRationalValueOf(1, 3)
+	if sum := third.Add(third).Add(third); !sum.IsInteger() || sum.String() != "1" {
+		t.Errorf("1/3 + 1/3 + 1/3 mismatch: %v", sum)
+	}
+	if got := bigger.BigRationalValueOf(-5, 2).Round(bigger.ROUND_HALF_EVEN); got.LongValue() != -2 {
+		t.Errorf("round half even of -5/2 mismatch: %v", got)
+	}
+	if got := bigger.NewBigRationalBigDecimal(bigger.NewBigDecimalString("-0.125")); got.String() != "-1/8" {
+		t.Errorf("from decimal mismatch: %v", got)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing repeating decimal expansions and their parsing
+func TestRepeatingDecimal(t *testing.T) {
+	for _, c := range []struct{ n, d, parens, overline string }{
+		{"1", "7", "0.(142857)", "0.1̅4̅2̅8̅5̅7̅"},
+		{"1", "6", "0.1(6)", "0.16̅"},
+		{"-1", "3", "-0.(3)", "-0.3̅"},
+		{"-250", "3", "-83.(3)", "-83.3̅"},
+		{"1", "12", "0.08(3)", "0.083̅"},
+		{"5", "4", "1.25", "1.25"},
+		{"7", "1", "7", "7"},
+		{"0", "5", "0", "0"},
+	} {
+		d := bigger.NewBigIntegerString(c.n).DivideRepeating(bigger.NewBigIntegerString(c.d))
+		if d.String() != c.parens || d.OverlineString() != c.overline {
+			t.Errorf("%s/%s expansion mismatch: %v %v", c.n, c.d, d, d.OverlineString())
+		}
+		want := bigger.NewBigRational(bigger.NewBigIntegerString(c.n), bigger.NewBigIntegerString(c.d))
+		if !bigger.NewBigRationalString(c.parens).Equals(want) || !bigger.NewBigRationalRepeating(c.overline).Equals(want) {
+			t.Errorf("%s parse mismatch", c.parens)
+		}
+	}
+	if d := bigger.NewBigDecimalString("1.5").DivideRepeating(bigger.NewBigDecimalString("0.7")); d.String() != "2.(142857)" {
+		t.Errorf("1.5/0.7 mismatch: %v", d)
+	}
+	// 2^521 - 1 is a prime with a period of about 2^520 digits
+	for _, c := range []struct {
+		n, d      string
+		maxPeriod types.Int
+		want      string
+	}{
+		{"1", "7", 6, "0.(142857)"},
+		{"1", "7", 5, ""},
+		{"1", "12", 1, "0.08(3)"},
+		{"3", "8", 0, "0.375"},
+		{"1", new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1)).String(), 1000, ""},
+	} {
+		d, ok := bigger.NewBigRational(bigger.NewBigIntegerString(c.n), bigger.NewBigIntegerString(c.d)).RepeatingDecimalLimit(c.maxPeriod)
+		if ok != (c.want != "") || ok && d.String() != c.want {
+			t.Errorf("%s/%s expansion within %d digits mismatch: %v %v", c.n, c.d, c.maxPeriod, d, ok)
+		}
+	}
+	// the period of 1/1048583 is 1048582 digits, past the default bound
+	p := bigger.NewBigIntegerString("1048583")
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("1/1048583 expansion past the default bound did not panic")
+			}
+		}()
+		bigger.BigIntegerValueOf(1).DivideRepeating(p)
+	}()
+	if d, ok := bigger.NewBigRational(bigger.BigIntegerValueOf(1), p).RepeatingDecimalLimit(1 << 21); !ok || len(d.Repetend()) != 1048582 {
+		t.Errorf("1/1048583 expansion within 2^21 digits mismatch: %v", ok)
+	}
+	for _, c := range []struct{ s, want string }{
+		{"0.(9)", "1"}, {"-.1(6)", "-1/6"}, {"1.2(36)", "68/55"}, {"+3.(0)", "3"},
+	} {
+		if got := bigger.NewBigRationalRepeating(c.s); got.String() != c.want {
+			t.Errorf("%s parse mismatch: %v", c.s, got)
+		}
+	}
+
+	r := rand.New(rand.NewSource(43))
+	for i := 0; i < 300; i++ {
+		n := randomBigInt(r, 1+r.Intn(4))
+		d := new(big.Int).Rand(r, big.NewInt(5000))
+		d.Add(d, big.NewInt(1)).Lsh(d, uint(r.Intn(80)))
+		if i%2 == 0 {
+			d.Mul(d, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(r.Intn(30))), nil))
+		}
+		x := new(big.Rat).SetFrac(n, d)
+		e := bigger.NewBigIntegerString(n.String()).DivideRepeating(bigger.NewBigIntegerString(d.String()))
+		if got, _ := new(big.Rat).SetString(e.BigRational().String()); got.Cmp(x) != 0 {
+			t.Fatalf("%v expansion value mismatch: %v", x, e)
+		}
+		if got, _ := new(big.Rat).SetString(bigger.NewBigRationalString(e.OverlineString()).String()); got.Cmp(x) != 0 {
+			t.Fatalf("%v overline parse mismatch", x)
+		}
+		// both parts are as short as possible
+		pre, rep := e.NonRepeating(), e.Repetend()
+		if pre != "" && rep != "" && pre[len(pre)-1] == rep[len(rep)-1] {
+			t.Fatalf("%v period starts late: %v", x, e)
+		}
+		for k := 1; k < len(rep); k++ {
+			if len(rep)%k == 0 && strings.Repeat(rep[:k], len(rep)/k) == rep {
+				t.Fatalf("%v repetend not minimal: %v", x, e)
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing Jacobi symbols and modular square roots, bigger.bigInteger vs bigInt
+func TestModSqrt(t *testing.T) {
+	r := rand.New(rand.NewSource(34))
+	primes := []*big.Int{big.NewInt(3), big.NewInt(13), big.NewInt(17)}
+	for _, size := range []int{1, 2, 8} {
+		p := new(big.Int).Abs(randomBigInt(r, size))
+		for ; len(primes) < 3+3*size; p.Add(p, big.NewInt(1)) {
+			if p.ProbablyPrime(20) {
+				primes = append(primes, new(big.Int).Set(p))
+			}
+		}
+	}
+	// the prime 25 * 2^64 + 1 has a large 2-power in p - 1, which selects Cipolla
+	primes = append(primes, new(big.Int).Add(new(big.Int).Lsh(big.NewInt(25), 64), big.NewInt(1)))
+	for _, p := range primes {
+		bp := bigger.NewBigIntegerString(p.String())
+		for k := 0; k < 10; k++ {
+			a := new(big.Int).Sub(new(big.Int).Rand(r, new(big.Int).Lsh(p, 1)), p)
+			ba := bigger.NewBigIntegerString(a.String())
+			if got := bigger.Jacobi(ba, bp); got != types.Int(big.Jacobi(a, p)) {
+				t.Errorf("jacobi (%s/%s) mismatch", a, p)
+			}
+			root, ok := bigger.ModSqrt(ba, bp)
+			if want := new(big.Int).ModSqrt(a, p); ok != (want != nil) {
+				t.Errorf("modSqrt of %s mod %s mismatch", a, p)
+			} else if ok && root.Multiply(root).Subtract(ba).Mod(bp).Signum() != 0 {
+				t.Errorf("modSqrt of %s mod %s is no root", a, p)
+			}
+		}
+	}
+	if got := bigger.Kronecker(bigger.BigIntegerValueOf(-3), bigger.BigIntegerValueOf(-20)); got != 1 {
+		t.Errorf("kronecker (-3/-20) mismatch %d", got)
+	}
+	if got := bigger.Kronecker(bigger.BigIntegerValueOf(5), bigger.BigIntegerValueOf(6)); got != 1 {
+		t.Errorf("kronecker (5/6) mismatch %d", got)
+	}
+	if got := bigger.Kronecker(bigger.BigIntegerValueOf(3), bigger.BigIntegerValueOf(2)); got != -1 {
+		t.Errorf("kronecker (3/2) mismatch %d", got)
+	}
+
+	// prime powers by brute force, including 2 and residues divisible by p
+	for _, p := range []types.Long{2, 3, 7} {
+		for pe, e := p, types.Int(1); pe < 3000; pe, e = pe*p, e+1 {
+			squares := map[types.Long]bool{}
+			for x := types.Long(0); x < pe; x++ {
+				squares[x*x%pe] = true
+			}
+			for a := types.Long(0); a < pe; a++ {
+				root, ok := bigger.ModSqrtPrimePower(bigger.BigIntegerValueOf(a), bigger.BigIntegerValueOf(p), e)
+				if ok != squares[a] || ok && (root.LongValue()*root.LongValue()-a)%pe != 0 {
+					t.Errorf("modSqrt of %d mod %d^%d mismatch", a, p, e)
+				}
+			}
+		}
+	}
+}
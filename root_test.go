@@ -0,0 +1,99 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+	"github.com/sineycoder/go-bigger/types"
+)
+
+// testing nth root, bigger.bigInteger vs bigInt
+func TestRoot(t *testing.T) {
+	r := rand.New(rand.NewSource(30))
+	for _, size := range []int{1, 2, 5, 40, 150} {
+		for _, n := range []int64{2, 3, 5, 7, 30} {
+			a := randomBigInt(r, size)
+			x := bigger.NewBigIntegerString(a.String())
+			s := x.Abs().Root(types.Int(n))
+			// s^n <= |a| < (s+1)^n
+			bs, _ := new(big.Int).SetString(s.String(), 10)
+			abs := new(big.Int).Abs(a)
+			lo := new(big.Int).Exp(bs, big.NewInt(n), nil)
+			hi := new(big.Int).Exp(new(big.Int).Add(bs, big.NewInt(1)), big.NewInt(n), nil)
+			if lo.Cmp(abs) > 0 || hi.Cmp(abs) <= 0 {
+				t.Errorf("root %d of %d ints mismatch", n, size)
+			}
+			sr := x.RootAndRemainder(3)
+			if sr[0].Pow(3).Add(sr[1]).String() != a.String() {
+				t.Errorf("root and remainder of %d ints mismatch", size)
+			}
+		}
+	}
+}
+
+func TestPerfectPower(t *testing.T) {
+	r := rand.New(rand.NewSource(31))
+	for _, size := range []int{1, 3, 20} {
+		for _, e := range []types.Int{2, 3, 6, 10} {
+			base := bigger.NewBigIntegerString(randomBigInt(r, size).String()).Abs()
+			x := base.Pow(e)
+			if !x.IsPerfectSquare() != (e%2 == 1) {
+				t.Errorf("perfect square %d^%d mismatch", size, e)
+			}
+			y, k, ok := x.IsPerfectPower()
+			if !ok || k%e != 0 || y.Pow(k).CompareTo(x) != 0 {
+				t.Errorf("perfect power %d^%d mismatch", size, e)
+			}
+			if _, _, ok := x.Add(bigger.BigIntegerValueOf(1)).IsPerfectPower(); ok && size > 1 {
+				t.Errorf("perfect power %d^%d + 1 mismatch", size, e)
+			}
+		}
+	}
+	if y, k, ok := bigger.BigIntegerValueOf(-243).IsPerfectPower(); !ok || k != 5 || y.String() != "-3" {
+		t.Errorf("perfect power of -243 mismatch")
+	}
+	if _, _, ok := bigger.BigIntegerValueOf(-16).IsPerfectPower(); ok {
+		t.Errorf("perfect power of -16 mismatch")
+	}
+}
+
+// testing roots of sparse values 2^e + 2^f + d, whose top ints round the
+// Newton seed down, bigger.bigInteger vs bigInt
+func TestRootSparse(t *testing.T) {
+	one := big.NewInt(1)
+	for e := uint(60); e < 1100; e += 37 {
+		for f := uint(0); f < e; f += 29 {
+			for _, d := range []int64{-1, 0, 1} {
+				a := new(big.Int).Add(new(big.Int).Lsh(one, e), new(big.Int).Lsh(one, f))
+				a.Add(a, big.NewInt(d))
+				x := bigger.NewBigIntegerString(a.String())
+				if x.Sqrt().String() != new(big.Int).Sqrt(a).String() {
+					t.Fatalf("sqrt of 2^%d + 2^%d + %d mismatch", e, f, d)
+				}
+				s, _ := new(big.Int).SetString(x.Root(3).String(), 10)
+				lo := new(big.Int).Exp(s, big.NewInt(3), nil)
+				hi := new(big.Int).Exp(new(big.Int).Add(s, one), big.NewInt(3), nil)
+				if lo.Cmp(a) > 0 || hi.Cmp(a) <= 0 {
+					t.Fatalf("root 3 of 2^%d + 2^%d + %d mismatch", e, f, d)
+				}
+			}
+		}
+	}
+
+	// (2^e + 1)^2 is a square the under-estimated seed missed
+	for _, e := range []uint{64, 100, 128, 200, 512} {
+		a := new(big.Int).Add(new(big.Int).Lsh(one, e), one)
+		x := bigger.NewBigIntegerString(new(big.Int).Mul(a, a).String())
+		if !x.IsPerfectSquare() {
+			t.Errorf("perfect square (2^%d + 1)^2 mismatch", e)
+		}
+		if y, k, ok := x.IsPerfectPower(); !ok || k != 2 || y.String() != a.String() {
+			t.Errorf("perfect power (2^%d + 1)^2 mismatch", e)
+		}
+		if x.Add(bigger.BigIntegerValueOf(1)).IsPerfectSquare() {
+			t.Errorf("perfect square (2^%d + 1)^2 + 1 mismatch", e)
+		}
+	}
+}
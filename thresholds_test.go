@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+)
+
+func TestThresholds(t *testing.T) {
+	defer bigger.SetThresholds(bigger.DefaultThresholds())
+	if bigger.GetThresholds() != bigger.DefaultThresholds() {
+		t.Fatalf("initial thresholds %+v differ from the defaults", bigger.GetThresholds())
+	}
+
+	var th bigger.Thresholds
+	if testing.Short() {
+		th = bigger.DefaultThresholds()
+		th.KaratsubaSquare, th.ToomCookSquare, th.BurnikelZiegler, th.SchoenhageBaseConversion = 8, 12, 8, 4
+	} else {
+		th = bigger.Calibrate()
+		if bigger.GetThresholds() != bigger.DefaultThresholds() {
+			t.Fatalf("Calibrate changed the thresholds in use")
+		}
+	}
+
+	// a calibrated set must survive persisting and loading
+	data, err := json.Marshal(th)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded bigger.Thresholds
+	if err = json.Unmarshal(data, &loaded); err != nil || loaded != th {
+		t.Fatalf("json round trip: got %+v, %v, want %+v", loaded, err, th)
+	}
+	bigger.SetThresholds(loaded)
+
+	r := rand.New(rand.NewSource(28))
+	for _, n := range []int{10, 100, 1000} {
+		a, b := randomBigInt(r, 2*n), randomBigInt(r, n)
+		x, y := bigger.NewBigIntegerString(a.String()), bigger.NewBigIntegerString(b.String())
+		if got, want := x.Multiply(y).String(), new(big.Int).Mul(a, b).String(); got != want {
+			t.Errorf("multiply %d ints mismatch with %+v", n, th)
+		}
+		if got, want := x.Divide(y).String(), new(big.Int).Quo(a, b).String(); got != want {
+			t.Errorf("divide %d ints mismatch with %+v", n, th)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetThresholds accepted a Karatsuba threshold of 1")
+		}
+	}()
+	th.KaratsubaSquare = 1
+	bigger.SetThresholds(th)
+}
@@ -2,7 +2,6 @@ package tool
 
 import (
 	"errors"
-	"math"
 
 	"github.com/sineycoder/go-bigger/types"
 )
@@ -12,24 +11,21 @@ import (
  @date: 2021/8/12 14:22:00
 **/
 
+// Copy returns a copy of original truncated or zero-padded to length.
 func Copy(original []types.Int, length types.Int) []types.Int {
-	if length >= types.Int(len(original)) {
-		return CopyRange(original, 0, length)
-	} else {
-		dest := make([]types.Int, length)
-		CopyRangePosLen(original, 0, dest, 0, types.Int(len(original)))
-		return dest
-	}
+	return CopyRange(original, 0, length)
 }
 
+// CopyRange returns original[from:to], zero-padded when to exceeds len(original).
 func CopyRange(original []types.Int, from, to types.Int) []types.Int {
 	newLength := to - from
 	if newLength < 0 {
 		panic(errors.New("invalid params"))
 	}
-	l := types.Int(math.Min(float64(len(original)), float64(newLength)))
-	cp := make([]types.Int, l)
-	copy(cp, original[from:from+l])
+	cp := make([]types.Int, newLength)
+	if from < types.Int(len(original)) {
+		copy(cp, original[from:])
+	}
 	return cp
 }
 
@@ -39,9 +35,7 @@ func CopyRangePosLen(src []types.Int, srcPos types.Int, dest []types.Int, destPo
 	if srcPos+length > srcLen || destPos+length > destLen {
 		panic("array out of index")
 	}
-	for ind := types.Int(0); ind < length; ind++ {
-		dest[destPos+ind] = src[srcPos+ind]
-	}
+	copy(dest[destPos:destPos+length], src[srcPos:srcPos+length])
 }
 
 func Fill(a []types.Int, fromIndex, toIndex, val types.Int) {
@@ -103,9 +97,7 @@ func Digit(a uint8, radix uint8) types.Int {
 }
 
 func Arraycopy(src []types.Int, srcPos types.Int, dest []types.Int, destPos, length types.Int) {
-	for i := types.Int(0); i < length; i++ {
-		dest[destPos+i] = src[srcPos+i]
-	}
+	copy(dest[destPos:destPos+length], src[srcPos:srcPos+length])
 }
 
 func ByteToInt(val []byte) (ret []types.Int) {
@@ -54,7 +54,10 @@ func (l Long) String() string {
 }
 
 func (l Long) Abs() Long {
-	return Long(math.Abs(float64(l)))
+	if l < 0 {
+		return -l
+	}
+	return l
 }
 
 func (l Long) Signum() Int {
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/sineycoder/go-bigger/bigger"
+)
+
+// testing workspace, bigger.bigInteger vs bigInt
+func TestWorkspace(t *testing.T) {
+	ws := bigger.NewWorkspace()
+	r := rand.New(rand.NewSource(29))
+	for _, n := range []int{1, 3, 30, 200, 700} {
+		for k := 0; k < 3; k++ {
+			a, b := randomBigInt(r, 2*n), randomBigInt(r, n)
+			x, y := bigger.NewBigIntegerString(a.String()), bigger.NewBigIntegerString(b.String())
+			q, m := new(big.Int).QuoRem(a, b, new(big.Int))
+			qr := ws.DivideAndRemainder(x, y)
+			if qr[0].String() != q.String() || qr[1].String() != m.String() {
+				t.Errorf("divideAndRemainder %d ints mismatch", n)
+			}
+			if got := ws.Divide(x, y).String(); got != q.String() {
+				t.Errorf("divide %d ints mismatch", n)
+			}
+			if got := ws.Sqrt(x.Abs()).String(); got != new(big.Int).Sqrt(new(big.Int).Abs(a)).String() {
+				t.Errorf("sqrt %d ints mismatch", n)
+			}
+			if got := ws.String(x); got != a.String() {
+				t.Errorf("string %d ints mismatch", n)
+			}
+			if got := ws.StringRadix(x, 7); got != a.Text(7) {
+				t.Errorf("string radix 7 %d ints mismatch", n)
+			}
+		}
+	}
+}
+
+// a workspace reuses its buffers and temporaries, so it allocates less than the shared pools
+func TestWorkspaceAllocs(t *testing.T) {
+	ws := bigger.NewWorkspace()
+	r := rand.New(rand.NewSource(29))
+	x := bigger.NewBigIntegerString(randomBigInt(r, 600).String()).Abs()
+	y := bigger.NewBigIntegerString(randomBigInt(r, 200).String())
+	for _, c := range []struct {
+		name      string
+		plain, ws func()
+	}{
+		{"divideAndRemainder", func() { x.DivideAndRemainder(y) }, func() { ws.DivideAndRemainder(x, y) }},
+		{"sqrt", func() { x.Sqrt() }, func() { ws.Sqrt(x) }},
+		{"string", func() { _ = x.String() }, func() { ws.String(x) }},
+	} {
+		plain, reused := testing.AllocsPerRun(20, c.plain), testing.AllocsPerRun(20, c.ws)
+		if reused >= plain {
+			t.Errorf("%s: %v allocations with a workspace, %v without", c.name, reused, plain)
+		}
+	}
+}
+
+func BenchmarkBiggerIntegerStringWorkspace(bb *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	a := bigger.NewBigIntegerString(randomBigInt(r, 400).String())
+	ws := bigger.NewWorkspace()
+	bb.ReportAllocs()
+	bb.ResetTimer()
+	for i := 0; i < bb.N; i++ {
+		_ = ws.String(a)
+	}
+}
+func BenchmarkBigintIntegerString(bb *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	a := randomBigInt(r, 400)
+	bb.ReportAllocs()
+	bb.ResetTimer()
+	for i := 0; i < bb.N; i++ {
+		_ = a.String()
+	}
+}